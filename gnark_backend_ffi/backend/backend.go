@@ -0,0 +1,66 @@
+// Package backend abstracts over gnark's proving systems so the rest of
+// this module can pick Groth16 (smaller proofs, circuit-specific trusted
+// setup) or PLONK (universal SRS, larger proofs) per circuit instead of
+// every call site branching on which one was chosen.
+package backend
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+)
+
+// Backend is the common surface ProveWithMeta-style callers need, whichever
+// proving system backs it. LoadSRS and GenerateSRS only matter for
+// universal-SRS systems like PLONK; Groth16's implementation no-ops them,
+// since its Setup is already circuit-specific and self-contained.
+type Backend interface {
+	// LoadSRS installs a previously-generated universal SRS.
+	LoadSRS(encodedSRS string) error
+	// GenerateSRS derives and installs an in-process universal SRS sized
+	// for ccs. Like a bare groth16.Setup, this carries toxic waste and is a
+	// development convenience, not a production substitute for LoadSRS
+	// with a ceremony-derived SRS (see the ceremony package for Groth16's
+	// equivalent).
+	GenerateSRS(ccs constraint.ConstraintSystem) error
+
+	// Setup derives this backend's proving and verifying keys for ccs.
+	Setup(ccs constraint.ConstraintSystem) error
+
+	Prove(ccs constraint.ConstraintSystem, fullWitness witness.Witness) (any, error)
+	Verify(proof any, publicWitness witness.Witness) error
+
+	MarshalProof(proof any) (string, error)
+	UnmarshalProof(encodedProof string) (any, error)
+
+	MarshalVK() (string, error)
+	UnmarshalVK(encodedVK string) error
+}
+
+// New returns the Backend registered under name ("groth16" or "plonk"),
+// bound to curveID for the rest of its lifetime.
+func New(name string, curveID ecc.ID) (Backend, error) {
+	switch name {
+	case "groth16":
+		return &groth16Backend{curveID: curveID}, nil
+	case "plonk":
+		return &plonkBackend{curveID: curveID}, nil
+	default:
+		return nil, fmt.Errorf("backend: unknown backend %q, want \"groth16\" or \"plonk\"", name)
+	}
+}
+
+// NewForCircuit is New, but takes the curveID gnark_backend_ffi/circuit.Build
+// already returned alongside ccs instead of making the caller name it a
+// second time. It can't read the curve off ccs itself:
+// constraint.ConstraintSystem doesn't expose CurveID() in this module's
+// gnark vintage, only the concrete per-curve types circuit.Build builds
+// internally do.
+//
+// See backend_test.go for a Groth16 round trip run against every curve
+// circuit.Build supports.
+func NewForCircuit(name string, ccs constraint.ConstraintSystem, curveID ecc.ID) (Backend, error) {
+	return New(name, curveID)
+}
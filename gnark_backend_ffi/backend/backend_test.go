@@ -0,0 +1,275 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"gnark_backend_ffi/circuit"
+	"gnark_backend_ffi/structs"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fr_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+)
+
+// curveFeltHex hex-encodes v's canonical field element representation on
+// curveID, the same per-curve encoding structs.DeserializeFelt reads.
+func curveFeltHex(curveID ecc.ID, v int64) string {
+	switch curveID {
+	case ecc.BN254:
+		var felt fr_bn254.Element
+		felt.SetInt64(v)
+		return hex.EncodeToString(felt.Marshal())
+	case ecc.BLS12_381:
+		var felt fr_bls12381.Element
+		felt.SetInt64(v)
+		return hex.EncodeToString(felt.Marshal())
+	case ecc.BLS12_377:
+		var felt fr_bls12377.Element
+		felt.SetInt64(v)
+		return hex.EncodeToString(felt.Marshal())
+	case ecc.BW6_761:
+		var felt fr_bw6761.Element
+		felt.SetInt64(v)
+		return hex.EncodeToString(felt.Marshal())
+	case ecc.BLS24_315:
+		var felt fr_bls24315.Element
+		felt.SetInt64(v)
+		return hex.EncodeToString(felt.Marshal())
+	default:
+		panic(fmt.Sprintf("curveFeltHex: unsupported curve %s", curveID))
+	}
+}
+
+// curveValuesHex hex-encodes vs as curveID's fr.Vector, via the same
+// MarshalBinary encoding structs.DeserializeFelts decodes with for every
+// curve but BN254 (which uses the length-prefixed EncodeFeltsTo codec
+// instead -- see circuit/r1cs_test.go's valuesHex).
+func curveValuesHex(curveID ecc.ID, vs ...int64) string {
+	switch curveID {
+	case ecc.BN254:
+		felts := make(fr_bn254.Vector, len(vs))
+		for i, v := range vs {
+			felts[i].SetInt64(v)
+		}
+		var buf bytes.Buffer
+		if err := structs.EncodeFeltsTo(&buf, felts); err != nil {
+			panic(err)
+		}
+		return hex.EncodeToString(buf.Bytes())
+	case ecc.BLS12_381:
+		felts := make(fr_bls12381.Vector, len(vs))
+		for i, v := range vs {
+			felts[i].SetInt64(v)
+		}
+		encoded, err := felts.MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		return hex.EncodeToString(encoded)
+	case ecc.BLS12_377:
+		felts := make(fr_bls12377.Vector, len(vs))
+		for i, v := range vs {
+			felts[i].SetInt64(v)
+		}
+		encoded, err := felts.MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		return hex.EncodeToString(encoded)
+	case ecc.BW6_761:
+		felts := make(fr_bw6761.Vector, len(vs))
+		for i, v := range vs {
+			felts[i].SetInt64(v)
+		}
+		encoded, err := felts.MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		return hex.EncodeToString(encoded)
+	case ecc.BLS24_315:
+		felts := make(fr_bls24315.Vector, len(vs))
+		for i, v := range vs {
+			felts[i].SetInt64(v)
+		}
+		encoded, err := felts.MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		return hex.EncodeToString(encoded)
+	default:
+		panic(fmt.Sprintf("curveValuesHex: unsupported curve %s", curveID))
+	}
+}
+
+// TestGroth16AcrossCurves runs the same ACIR-derived gate -- Y = X1 + X2 --
+// end-to-end (circuit.Build, then a real groth16 Setup/Prove/Verify round
+// trip through backend.NewForCircuit) on every curve circuit.Build
+// supports, so a curve-specific encoding mistake in either package shows up
+// as one curve's subtest failing rather than a difference only caught in
+// production.
+func TestGroth16AcrossCurves(t *testing.T) {
+	curves := []struct {
+		name string
+		id   ecc.ID
+	}{
+		{"BN254", ecc.BN254},
+		{"BLS12_381", ecc.BLS12_381},
+		{"BLS12_377", ecc.BLS12_377},
+		{"BW6_761", ecc.BW6_761},
+		{"BLS24_315", ecc.BLS24_315},
+	}
+
+	for _, curve := range curves {
+		t.Run(curve.name, func(t *testing.T) {
+			r := structs.RawR1CS{
+				Curve: curve.name,
+				Gates: []structs.RawGate{{
+					AddTerms: []structs.AddTerm{
+						{Coefficient: curveFeltHex(curve.id, 1), Sum: 0},
+						{Coefficient: curveFeltHex(curve.id, -1), Sum: 1},
+						{Coefficient: curveFeltHex(curve.id, -1), Sum: 2},
+					},
+					ConstantTerm: curveFeltHex(curve.id, 0),
+				}},
+				PublicInputs:   []uint32{1},
+				Values:         curveValuesHex(curve.id, 5, 2, 3), // Y=5, X1=2, X2=3
+				NumVariables:   3,
+				NumConstraints: 1,
+			}
+
+			ccs, curveID, w, err := circuit.Build(r)
+			if err != nil {
+				t.Fatalf("circuit.Build: %v", err)
+			}
+			if curveID != curve.id {
+				t.Fatalf("circuit.Build returned curveID %s, want %s", curveID, curve.id)
+			}
+
+			b, err := NewForCircuit("groth16", ccs, curveID)
+			if err != nil {
+				t.Fatalf("NewForCircuit: %v", err)
+			}
+
+			if err := b.Setup(ccs); err != nil {
+				t.Fatalf("Setup: %v", err)
+			}
+
+			proof, err := b.Prove(ccs, w)
+			if err != nil {
+				t.Fatalf("Prove: %v", err)
+			}
+
+			publicWitness, err := w.Public()
+			if err != nil {
+				t.Fatalf("witness.Public: %v", err)
+			}
+
+			if err := b.Verify(proof, publicWitness); err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+
+			encodedProof, err := b.MarshalProof(proof)
+			if err != nil {
+				t.Fatalf("MarshalProof: %v", err)
+			}
+			decodedProof, err := b.UnmarshalProof(encodedProof)
+			if err != nil {
+				t.Fatalf("UnmarshalProof: %v", err)
+			}
+			if err := b.Verify(decodedProof, publicWitness); err != nil {
+				t.Fatalf("Verify(round-tripped proof): %v", err)
+			}
+		})
+	}
+}
+
+// TestPlonkAcrossCurves is TestGroth16AcrossCurves' PLONK counterpart: the
+// same ACIR-derived gate, but built via circuit.BuildSparse and proved
+// against an in-process GenerateSRS instead of Groth16's circuit-specific
+// Setup, so a curve-specific mistake in either circuit.BuildSparse or
+// plonkBackend shows up as one curve's subtest failing.
+func TestPlonkAcrossCurves(t *testing.T) {
+	curves := []struct {
+		name string
+		id   ecc.ID
+	}{
+		{"BN254", ecc.BN254},
+		{"BLS12_381", ecc.BLS12_381},
+		{"BLS12_377", ecc.BLS12_377},
+		{"BW6_761", ecc.BW6_761},
+		{"BLS24_315", ecc.BLS24_315},
+	}
+
+	for _, curve := range curves {
+		t.Run(curve.name, func(t *testing.T) {
+			r := structs.RawR1CS{
+				Curve: curve.name,
+				Gates: []structs.RawGate{{
+					AddTerms: []structs.AddTerm{
+						{Coefficient: curveFeltHex(curve.id, 1), Sum: 0},
+						{Coefficient: curveFeltHex(curve.id, -1), Sum: 1},
+						{Coefficient: curveFeltHex(curve.id, -1), Sum: 2},
+					},
+					ConstantTerm: curveFeltHex(curve.id, 0),
+				}},
+				PublicInputs:   []uint32{1},
+				Values:         curveValuesHex(curve.id, 5, 2, 3), // Y=5, X1=2, X2=3
+				NumVariables:   3,
+				NumConstraints: 1,
+			}
+
+			ccs, curveID, w, err := circuit.BuildSparse(r)
+			if err != nil {
+				t.Fatalf("circuit.BuildSparse: %v", err)
+			}
+			if curveID != curve.id {
+				t.Fatalf("circuit.BuildSparse returned curveID %s, want %s", curveID, curve.id)
+			}
+
+			b, err := NewForCircuit("plonk", ccs, curveID)
+			if err != nil {
+				t.Fatalf("NewForCircuit: %v", err)
+			}
+
+			if err := b.GenerateSRS(ccs); err != nil {
+				t.Fatalf("GenerateSRS: %v", err)
+			}
+
+			if err := b.Setup(ccs); err != nil {
+				t.Fatalf("Setup: %v", err)
+			}
+
+			proof, err := b.Prove(ccs, w)
+			if err != nil {
+				t.Fatalf("Prove: %v", err)
+			}
+
+			publicWitness, err := w.Public()
+			if err != nil {
+				t.Fatalf("witness.Public: %v", err)
+			}
+
+			if err := b.Verify(proof, publicWitness); err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+
+			encodedProof, err := b.MarshalProof(proof)
+			if err != nil {
+				t.Fatalf("MarshalProof: %v", err)
+			}
+			decodedProof, err := b.UnmarshalProof(encodedProof)
+			if err != nil {
+				t.Fatalf("UnmarshalProof: %v", err)
+			}
+			if err := b.Verify(decodedProof, publicWitness); err != nil {
+				t.Fatalf("Verify(round-tripped proof): %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+)
+
+// groth16Backend wraps gnark's Groth16 prover. Its trusted setup is
+// circuit-specific, so LoadSRS and GenerateSRS are no-ops: there is no
+// universal SRS to install ahead of time.
+type groth16Backend struct {
+	curveID ecc.ID
+
+	pk groth16.ProvingKey
+	vk groth16.VerifyingKey
+}
+
+func (b *groth16Backend) LoadSRS(encodedSRS string) error { return nil }
+
+func (b *groth16Backend) GenerateSRS(ccs constraint.ConstraintSystem) error { return nil }
+
+func (b *groth16Backend) Setup(ccs constraint.ConstraintSystem) error {
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		return err
+	}
+	b.pk, b.vk = pk, vk
+	return nil
+}
+
+func (b *groth16Backend) Prove(ccs constraint.ConstraintSystem, fullWitness witness.Witness) (any, error) {
+	if b.pk == nil {
+		return nil, fmt.Errorf("backend: groth16 Setup must run before Prove")
+	}
+	return groth16.Prove(ccs, b.pk, fullWitness)
+}
+
+func (b *groth16Backend) Verify(proof any, publicWitness witness.Witness) error {
+	if b.vk == nil {
+		return fmt.Errorf("backend: groth16 Setup or UnmarshalVK must run before Verify")
+	}
+	p, ok := proof.(groth16.Proof)
+	if !ok {
+		return fmt.Errorf("backend: not a groth16 proof: %T", proof)
+	}
+	return groth16.Verify(p, b.vk, publicWitness)
+}
+
+func (b *groth16Backend) MarshalProof(proof any) (string, error) {
+	p, ok := proof.(groth16.Proof)
+	if !ok {
+		return "", fmt.Errorf("backend: not a groth16 proof: %T", proof)
+	}
+	var buf bytes.Buffer
+	if _, err := p.WriteTo(&buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+func (b *groth16Backend) UnmarshalProof(encodedProof string) (any, error) {
+	decoded, err := hex.DecodeString(encodedProof)
+	if err != nil {
+		return nil, err
+	}
+	proof := groth16.NewProof(b.curveID)
+	if _, err := proof.ReadFrom(bytes.NewReader(decoded)); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+func (b *groth16Backend) MarshalVK() (string, error) {
+	if b.vk == nil {
+		return "", fmt.Errorf("backend: groth16 Setup must run before MarshalVK")
+	}
+	var buf bytes.Buffer
+	if _, err := b.vk.WriteTo(&buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+func (b *groth16Backend) UnmarshalVK(encodedVK string) error {
+	decoded, err := hex.DecodeString(encodedVK)
+	if err != nil {
+		return err
+	}
+	vk := groth16.NewVerifyingKey(b.curveID)
+	if _, err := vk.ReadFrom(bytes.NewReader(decoded)); err != nil {
+		return err
+	}
+	b.vk = vk
+	return nil
+}
@@ -0,0 +1,224 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	kzg_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/kzg"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/kzg"
+	fr_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	kzg_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/kzg"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	kzg_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/kzg"
+	"github.com/consensys/gnark-crypto/kzg"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+)
+
+// plonkBackend wraps gnark's PLONK prover. Unlike Groth16, PLONK needs a
+// universal KZG SRS before Setup can run, so LoadSRS/GenerateSRS are real
+// here instead of no-ops. It imports gnark-crypto's per-curve kzg package
+// from its fr subpackage (ecc/<curve>/fr/kzg) -- the path gnark v0.8.0's own
+// backend/plonk imports -- rather than the top-level ecc/<curve>/kzg path,
+// which is a v0.13+ addition this module's pinned gnark-crypto predates.
+type plonkBackend struct {
+	curveID ecc.ID
+
+	srs kzg.SRS
+	pk  plonk.ProvingKey
+	vk  plonk.VerifyingKey
+}
+
+// LoadSRS installs a previously-generated KZG SRS (as produced by
+// gnark-crypto's kzg.SRS.WriteTo), the same transcript plonk.go's
+// //export LoadSRS expects.
+func (b *plonkBackend) LoadSRS(encodedSRS string) error {
+	decoded, err := hex.DecodeString(encodedSRS)
+	if err != nil {
+		return err
+	}
+
+	var srs kzg.SRS
+	switch b.curveID {
+	case ecc.BN254:
+		s := &kzg_bn254.SRS{}
+		_, err = s.ReadFrom(bytes.NewReader(decoded))
+		srs = s
+	case ecc.BLS12_381:
+		s := &kzg_bls12381.SRS{}
+		_, err = s.ReadFrom(bytes.NewReader(decoded))
+		srs = s
+	case ecc.BLS12_377:
+		s := &kzg_bls12377.SRS{}
+		_, err = s.ReadFrom(bytes.NewReader(decoded))
+		srs = s
+	case ecc.BW6_761:
+		s := &kzg_bw6761.SRS{}
+		_, err = s.ReadFrom(bytes.NewReader(decoded))
+		srs = s
+	case ecc.BLS24_315:
+		s := &kzg_bls24315.SRS{}
+		_, err = s.ReadFrom(bytes.NewReader(decoded))
+		srs = s
+	default:
+		return fmt.Errorf("backend: unsupported curve %s", b.curveID)
+	}
+	if err != nil {
+		return err
+	}
+
+	b.srs = srs
+	return nil
+}
+
+// GenerateSRS derives an in-process KZG SRS sized for ccs. Its secret is
+// sampled and discarded in memory, never persisted, so this is a
+// development convenience for circuits that don't have a ceremony-derived
+// SRS to load yet, not a production substitute for LoadSRS.
+func (b *plonkBackend) GenerateSRS(ccs constraint.ConstraintSystem) error {
+	size := srsSizeFor(ccs)
+
+	var srs kzg.SRS
+	var err error
+	switch b.curveID {
+	case ecc.BN254:
+		var alpha fr_bn254.Element
+		alpha.SetRandom()
+		var alphaBig big.Int
+		alpha.BigInt(&alphaBig)
+		srs, err = kzg_bn254.NewSRS(size, &alphaBig)
+	case ecc.BLS12_381:
+		var alpha fr_bls12381.Element
+		alpha.SetRandom()
+		var alphaBig big.Int
+		alpha.BigInt(&alphaBig)
+		srs, err = kzg_bls12381.NewSRS(size, &alphaBig)
+	case ecc.BLS12_377:
+		var alpha fr_bls12377.Element
+		alpha.SetRandom()
+		var alphaBig big.Int
+		alpha.BigInt(&alphaBig)
+		srs, err = kzg_bls12377.NewSRS(size, &alphaBig)
+	case ecc.BW6_761:
+		var alpha fr_bw6761.Element
+		alpha.SetRandom()
+		var alphaBig big.Int
+		alpha.BigInt(&alphaBig)
+		srs, err = kzg_bw6761.NewSRS(size, &alphaBig)
+	case ecc.BLS24_315:
+		var alpha fr_bls24315.Element
+		alpha.SetRandom()
+		var alphaBig big.Int
+		alpha.BigInt(&alphaBig)
+		srs, err = kzg_bls24315.NewSRS(size, &alphaBig)
+	default:
+		return fmt.Errorf("backend: unsupported curve %s", b.curveID)
+	}
+	if err != nil {
+		return err
+	}
+
+	b.srs = srs
+	return nil
+}
+
+// srsSizeFor returns the KZG SRS size PLONK's own Setup needs: the next
+// power of two at or above the constraint count plus the public variable
+// count (gnark's Setup adds one placeholder constraint per public
+// variable), plus the small margin its blinding factors need on top of
+// that evaluation domain. This mirrors gnark's own test.NewKZGSRS helper,
+// which computes the same size for the SRS it hands to plonk.Setup.
+func srsSizeFor(ccs constraint.ConstraintSystem) uint64 {
+	sizeSystem := uint64(ccs.GetNbConstraints() + ccs.GetNbPublicVariables())
+	size := uint64(1)
+	for size < sizeSystem {
+		size <<= 1
+	}
+	return size + 3
+}
+
+func (b *plonkBackend) Setup(ccs constraint.ConstraintSystem) error {
+	if b.srs == nil {
+		return fmt.Errorf("backend: plonk Setup needs an SRS, call LoadSRS or GenerateSRS first")
+	}
+	pk, vk, err := plonk.Setup(ccs, b.srs)
+	if err != nil {
+		return err
+	}
+	b.pk, b.vk = pk, vk
+	return nil
+}
+
+func (b *plonkBackend) Prove(ccs constraint.ConstraintSystem, fullWitness witness.Witness) (any, error) {
+	if b.pk == nil {
+		return nil, fmt.Errorf("backend: plonk Setup must run before Prove")
+	}
+	return plonk.Prove(ccs, b.pk, fullWitness)
+}
+
+func (b *plonkBackend) Verify(proof any, publicWitness witness.Witness) error {
+	if b.vk == nil {
+		return fmt.Errorf("backend: plonk Setup or UnmarshalVK must run before Verify")
+	}
+	p, ok := proof.(plonk.Proof)
+	if !ok {
+		return fmt.Errorf("backend: not a plonk proof: %T", proof)
+	}
+	return plonk.Verify(p, b.vk, publicWitness)
+}
+
+func (b *plonkBackend) MarshalProof(proof any) (string, error) {
+	p, ok := proof.(plonk.Proof)
+	if !ok {
+		return "", fmt.Errorf("backend: not a plonk proof: %T", proof)
+	}
+	var buf bytes.Buffer
+	if _, err := p.WriteTo(&buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+func (b *plonkBackend) UnmarshalProof(encodedProof string) (any, error) {
+	decoded, err := hex.DecodeString(encodedProof)
+	if err != nil {
+		return nil, err
+	}
+	proof := plonk.NewProof(b.curveID)
+	if _, err := proof.ReadFrom(bytes.NewReader(decoded)); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+func (b *plonkBackend) MarshalVK() (string, error) {
+	if b.vk == nil {
+		return "", fmt.Errorf("backend: plonk Setup must run before MarshalVK")
+	}
+	var buf bytes.Buffer
+	if _, err := b.vk.WriteTo(&buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+func (b *plonkBackend) UnmarshalVK(encodedVK string) error {
+	decoded, err := hex.DecodeString(encodedVK)
+	if err != nil {
+		return err
+	}
+	vk := plonk.NewVerifyingKey(b.curveID)
+	if _, err := vk.ReadFrom(bytes.NewReader(decoded)); err != nil {
+		return err
+	}
+	b.vk = vk
+	return nil
+}
@@ -0,0 +1,128 @@
+// Package ceremony implements a Powers-of-Tau / Groth16 phase-2 multi-party
+// computation: Phase2Init/Phase2Contribute/VerifyContribution build and
+// publicly audit a real delta accumulator no single party ever learns the
+// discrete log of. It only targets BN254, matching the same restriction
+// solidity.go already applies to EVM-facing output.
+//
+// It does not, today, get a caller all the way to a proving/verifying key
+// derived from that accumulator: Phase2Finalize always returns
+// ErrFinalizeNotImplemented (see its doc comment for why gnark's public
+// groth16 API leaves no honest way to do that substitution), so this
+// package cannot yet replace the toxic-waste footgun it exists to get away
+// from. main.go's ProveWithMeta/VerifyWithMeta/Preprocess -- the FFI's
+// original entry points -- still call groth16.Setup(r1cs) directly and are
+// untouched by this package; a caller who wants this module's proving keys
+// to stop depending on a single in-process Setup has no path to that yet,
+// only the (real, auditable) contribution-accumulation half of one.
+//
+// LoadPhase1 reads this package's own gnark-encoded transcript format (see
+// the size constants below), not a third-party Powers-of-Tau file: a real
+// Hermez/Snarkjs .ptau uses a section-tagged container with its own point
+// encoding (raw little-endian coordinates, different section sizes for
+// tauG1/tauG2) that this package does not parse. Ingesting one of those
+// directly would need a conversion step this package doesn't implement;
+// until then, a phase-1 transcript has to be produced by something that
+// writes this package's format, not sourced from an existing public
+// ceremony.
+package ceremony
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// Sizes of the uncompressed point encodings this package reads and writes.
+const (
+	g1AffineSize = 64  // two 32-byte Fp coordinates
+	g2AffineSize = 128 // two 64-byte Fp2 coordinates
+)
+
+// SRS is a phase-1 Powers-of-Tau transcript: the τ-powers in G1 and G2 plus
+// the α and β shifted τ-powers Phase2Init needs to specialize a circuit
+// without ever learning τ, α, or β themselves.
+type SRS struct {
+	Power uint8
+
+	G1      []bn254.G1Affine // 1, τ, τ², ..., τ^(2^Power - 1)
+	G2      []bn254.G2Affine // 1, τ
+	AlphaG1 []bn254.G1Affine // α·τ^i, one per G1 power
+	BetaG1  []bn254.G1Affine // β·τ^i, one per G1 power
+	BetaG2  bn254.G2Affine
+}
+
+// LoadPhase1 reads a phase-1 transcript from path and checks that it has
+// enough τ-powers for a circuit of at least 2^power constraints.
+func LoadPhase1(path string, power uint8) (*SRS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("ceremony: opening phase-1 transcript: %w", err)
+	}
+	defer f.Close()
+
+	var transcriptPower uint8
+	if err := binary.Read(f, binary.BigEndian, &transcriptPower); err != nil {
+		return nil, fmt.Errorf("ceremony: reading phase-1 header: %w", err)
+	}
+	if transcriptPower < power {
+		return nil, fmt.Errorf("ceremony: phase-1 transcript only supports 2^%d constraints, need 2^%d", transcriptPower, power)
+	}
+
+	size := 1 << power
+	srs := &SRS{
+		Power:   power,
+		G1:      make([]bn254.G1Affine, size),
+		G2:      make([]bn254.G2Affine, 2),
+		AlphaG1: make([]bn254.G1Affine, size),
+		BetaG1:  make([]bn254.G1Affine, size),
+	}
+
+	if err := readG1Vector(f, srs.G1); err != nil {
+		return nil, fmt.Errorf("ceremony: reading G1 powers: %w", err)
+	}
+	if err := readG2Vector(f, srs.G2); err != nil {
+		return nil, fmt.Errorf("ceremony: reading G2 powers: %w", err)
+	}
+	if err := readG1Vector(f, srs.AlphaG1); err != nil {
+		return nil, fmt.Errorf("ceremony: reading alpha*G1 powers: %w", err)
+	}
+	if err := readG1Vector(f, srs.BetaG1); err != nil {
+		return nil, fmt.Errorf("ceremony: reading beta*G1 powers: %w", err)
+	}
+	betaG2 := make([]bn254.G2Affine, 1)
+	if err := readG2Vector(f, betaG2); err != nil {
+		return nil, fmt.Errorf("ceremony: reading beta*G2: %w", err)
+	}
+	srs.BetaG2 = betaG2[0]
+
+	return srs, nil
+}
+
+func readG1Vector(r io.Reader, points []bn254.G1Affine) error {
+	buf := make([]byte, g1AffineSize)
+	for i := range points {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return fmt.Errorf("point %d: %w", i, err)
+		}
+		if err := points[i].Unmarshal(buf); err != nil {
+			return fmt.Errorf("point %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func readG2Vector(r io.Reader, points []bn254.G2Affine) error {
+	buf := make([]byte, g2AffineSize)
+	for i := range points {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return fmt.Errorf("point %d: %w", i, err)
+		}
+		if err := points[i].Unmarshal(buf); err != nil {
+			return fmt.Errorf("point %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package ceremony
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint"
+)
+
+// Phase2State is one step of a Groth16 phase-2 specialization: the
+// circuit's phase-1 SRS, the δ toxic-waste accumulator folded in by every
+// contributor so far (in both groups, so VerifyContribution can pair
+// against it without learning δ), and a hash chain binding each
+// contribution to the ones before it.
+//
+// Only δ is rerandomized here, not γ: γ has no circuit-specific or
+// per-contributor structure in Groth16 (it only gates the public-input
+// linear combination), so this package fixes it at 1 — the same convention
+// gnark's own in-process groth16.Setup uses — and folds every
+// contribution's randomness into δ alone.
+type Phase2State struct {
+	r1cs constraint.ConstraintSystem
+	srs  *SRS
+
+	Delta1 bn254.G1Affine
+	Delta2 bn254.G2Affine
+
+	Transcript []byte
+}
+
+// Phase2Init seeds a phase-2 ceremony for r1cs from a phase-1 SRS: it checks
+// the SRS covers the circuit's constraint count and curve, and starts the
+// δ accumulator at the group generators (i.e. δ = 1, no contribution yet).
+//
+// curveID is taken as a separate argument, rather than read off r1cs, since
+// constraint.ConstraintSystem doesn't expose CurveID() in this module's
+// gnark vintage; only the concrete per-curve types circuit.Build
+// constructs do, and circuit.Build already hands curveID back alongside
+// the interface for exactly this reason.
+func Phase2Init(r1cs constraint.ConstraintSystem, curveID ecc.ID, srs *SRS) (*Phase2State, error) {
+	if curveID != ecc.BN254 {
+		return nil, fmt.Errorf("ceremony: phase-2 ceremonies are only supported for BN254 circuits, got %s", curveID)
+	}
+	if nbConstraints := r1cs.GetNbConstraints(); 1<<srs.Power < nbConstraints {
+		return nil, fmt.Errorf("ceremony: phase-1 transcript only supports 2^%d constraints, circuit has %d", srs.Power, nbConstraints)
+	}
+
+	_, _, g1Gen, g2Gen := bn254.Generators()
+
+	return &Phase2State{
+		r1cs:       r1cs,
+		srs:        srs,
+		Delta1:     g1Gen,
+		Delta2:     g2Gen,
+		Transcript: sha256Sum(g1Gen.Marshal(), g2Gen.Marshal()),
+	}, nil
+}
+
+// Phase2Contribute folds a contributor's entropy into the δ accumulator,
+// returning a new state so the previous one remains around for
+// VerifyContribution to check the update against. entropy is hashed
+// together with the running transcript to derive the contribution's
+// (secret, never returned) scalar, so two contributors supplying the same
+// entropy against different prior states still produce unlinkable updates.
+func Phase2Contribute(state *Phase2State, entropy []byte) (*Phase2State, error) {
+	var scalar fr.Element
+	scalar.SetBytes(sha256Sum(state.Transcript, entropy))
+	if scalar.IsZero() {
+		return nil, fmt.Errorf("ceremony: contribution entropy hashed to zero, pick different entropy")
+	}
+
+	var scalarBig big.Int
+	scalar.BigInt(&scalarBig)
+
+	var nextDelta1 bn254.G1Affine
+	nextDelta1.ScalarMultiplication(&state.Delta1, &scalarBig)
+
+	var nextDelta2 bn254.G2Affine
+	nextDelta2.ScalarMultiplication(&state.Delta2, &scalarBig)
+
+	return &Phase2State{
+		r1cs:       state.r1cs,
+		srs:        state.srs,
+		Delta1:     nextDelta1,
+		Delta2:     nextDelta2,
+		Transcript: sha256Sum(state.Transcript, nextDelta1.Marshal(), nextDelta2.Marshal()),
+	}, nil
+}
+
+// VerifyContribution checks that next only multiplied prev's δ accumulator
+// by some scalar its contributor alone knew, without that scalar ever being
+// revealed: e(prev.Delta1, next.Delta2) == e(next.Delta1, prev.Delta2) holds
+// iff next.DeltaG = prev.DeltaG^s in both groups for the same s, the
+// standard same-ratio pairing check used to audit Groth16/Powers-of-Tau
+// ceremony transcripts.
+func VerifyContribution(prev, next *Phase2State) error {
+	var negPrevDelta1 bn254.G1Affine
+	negPrevDelta1.Neg(&prev.Delta1)
+
+	ok, err := bn254.PairingCheck(
+		[]bn254.G1Affine{next.Delta1, negPrevDelta1},
+		[]bn254.G2Affine{prev.Delta2, next.Delta2},
+	)
+	if err != nil {
+		return fmt.Errorf("ceremony: verifying contribution: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("ceremony: contribution did not update delta by a consistent scalar")
+	}
+	return nil
+}
+
+// ErrFinalizeNotImplemented is returned by Phase2Finalize unconditionally.
+// Substituting this package's publicly-verifiable δ accumulator into a
+// Groth16 proving/verifying key requires rebuilding the L/A/B/H query
+// commitments from the circuit and the phase-1 τ-powers under that δ --
+// gnark's public groth16 package (the only API surface this module vendors
+// against) exposes no hook to do that, only a single opaque groth16.Setup
+// that draws its own fresh, undisclosed toxic waste. Calling groth16.Setup
+// here instead and handing back its keys would look like ceremony output
+// while actually being exactly the single-party trusted setup this package
+// exists to get away from, so Phase2Finalize refuses rather than ship that.
+//
+// The rest of this package is real: Phase2Init/Phase2Contribute/
+// VerifyContribution build and publicly audit a genuine δ accumulator.
+// Finishing the job needs either a newer gnark version that exposes
+// phase-2 key specialization, or reimplementing Groth16 setup's query-
+// polynomial construction in this package -- both out of scope here.
+var ErrFinalizeNotImplemented = errors.New("ceremony: finalizing a phase-2 ceremony into a re-keyed proving/verifying key is not implemented; gnark's public groth16 API exposes no way to substitute an externally-accumulated delta into Setup's output, so no key is returned rather than one that only looks ceremony-backed")
+
+// Phase2Finalize would derive the circuit's (pk, vk) pair from the
+// completed ceremony. See ErrFinalizeNotImplemented: it always returns
+// that error instead, since there is no honest way to produce real
+// ceremony-backed keys against gnark's current public API.
+func Phase2Finalize(state *Phase2State) (groth16.ProvingKey, groth16.VerifyingKey, error) {
+	if len(state.Transcript) == 0 {
+		return nil, nil, fmt.Errorf("ceremony: phase-2 state has no transcript, was it built via Phase2Init?")
+	}
+	return nil, nil, ErrFinalizeNotImplemented
+}
+
+func sha256Sum(parts ...[]byte) []byte {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write(p)
+	}
+	return h.Sum(nil)
+}
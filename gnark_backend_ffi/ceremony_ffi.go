@@ -0,0 +1,173 @@
+package main
+
+import "C"
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	"gnark_backend_ffi/ceremony"
+	"gnark_backend_ffi/circuit"
+	"gnark_backend_ffi/structs"
+)
+
+// phase1Cache holds phase-1 SRS transcripts loaded via LoadPhase1Ceremony,
+// keyed by the path they were loaded from, the same way plonk.go's srsCache
+// keys a loaded KZG SRS by curve instead of reloading it from disk on every
+// call.
+var (
+	phase1CacheMu sync.RWMutex
+	phase1Cache   = map[string]*ceremony.SRS{}
+)
+
+// phase2Cache holds in-flight phase-2 states keyed by an opaque handle, so a
+// ceremony can be driven across several FFI calls (one per contributor)
+// without the Go side having to round-trip the δ accumulator's point
+// encoding through Rust on every step.
+var (
+	phase2CacheMu sync.Mutex
+	phase2Cache   = map[string]*ceremony.Phase2State{}
+	phase2Counter uint64
+)
+
+// LoadPhase1Ceremony loads the phase-1 Powers-of-Tau transcript at path,
+// caches it, and returns path back as the handle later passed to
+// InitPhase2. path names a file the caller hands in, so a malformed or
+// missing transcript is an ordinary error, not a reason to crash the host
+// process the way this used to with log.Fatal -- it comes back as the
+// second return, empty on success, the same convention
+// VerifyPhase2Contribution already uses.
+//
+//export LoadPhase1Ceremony
+func LoadPhase1Ceremony(path string, power uint32) (*C.char, *C.char) {
+	srs, err := ceremony.LoadPhase1(path, uint8(power))
+	if err != nil {
+		return C.CString(""), C.CString(err.Error())
+	}
+
+	phase1CacheMu.Lock()
+	phase1Cache[path] = srs
+	phase1CacheMu.Unlock()
+
+	return C.CString(path), C.CString("")
+}
+
+// InitPhase2 starts a phase-2 ceremony for the circuit described by rawR1CS
+// against the phase-1 transcript previously loaded under phase1Handle,
+// caches the resulting state, and returns a handle for ContributePhase2 and
+// FinalizePhase2.
+//
+//export InitPhase2
+func InitPhase2(rawR1CS string, phase1Handle string) *C.char {
+	var r structs.RawR1CS
+	if err := json.Unmarshal([]byte(rawR1CS), &r); err != nil {
+		log.Fatal(err)
+	}
+
+	r1cs, curveID, _, err := circuit.Build(r)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	phase1CacheMu.RLock()
+	srs, ok := phase1Cache[phase1Handle]
+	phase1CacheMu.RUnlock()
+	if !ok {
+		log.Fatal(fmt.Errorf("ceremony: no phase-1 transcript loaded under handle %q, call LoadPhase1Ceremony first", phase1Handle))
+	}
+
+	state, err := ceremony.Phase2Init(r1cs, curveID, srs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return C.CString(cachePhase2State(state))
+}
+
+// ContributePhase2 folds entropy into the δ accumulator of the phase-2
+// state behind handle, caches the resulting state under a new handle, and
+// returns it. The previous handle keeps pointing at the pre-contribution
+// state so a caller can still feed both into VerifyPhase2Contribution.
+//
+//export ContributePhase2
+func ContributePhase2(handle string, entropy string) *C.char {
+	phase2CacheMu.Lock()
+	state, ok := phase2Cache[handle]
+	phase2CacheMu.Unlock()
+	if !ok {
+		log.Fatal(fmt.Errorf("ceremony: no phase-2 state cached under handle %q", handle))
+	}
+
+	next, err := ceremony.Phase2Contribute(state, []byte(entropy))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return C.CString(cachePhase2State(next))
+}
+
+// VerifyPhase2Contribution checks that the phase-2 state behind nextHandle
+// only multiplied prevHandle's δ accumulator by some scalar its contributor
+// alone knew. It returns "" on success and an error message otherwise, the
+// same error-or-empty convention LoadSRS uses.
+//
+//export VerifyPhase2Contribution
+func VerifyPhase2Contribution(prevHandle string, nextHandle string) *C.char {
+	phase2CacheMu.Lock()
+	prev, prevOK := phase2Cache[prevHandle]
+	next, nextOK := phase2Cache[nextHandle]
+	phase2CacheMu.Unlock()
+	if !prevOK || !nextOK {
+		return C.CString(fmt.Sprintf("ceremony: no phase-2 state cached under handle %q or %q", prevHandle, nextHandle))
+	}
+
+	if err := ceremony.VerifyContribution(prev, next); err != nil {
+		return C.CString(err.Error())
+	}
+	return C.CString("")
+}
+
+// FinalizePhase2 would derive the (pk, vk) pair for the phase-2 state
+// behind handle, hex-encoded the same way Preprocess encodes them, as its
+// first two returns. It currently always fails with
+// ceremony.ErrFinalizeNotImplemented: see that error's doc comment for why
+// there is no honest way yet to substitute this ceremony's accumulated
+// delta into a real proving/verifying key. That failure is the expected
+// outcome of every call today, not a caller error, so it comes back as the
+// third return's error string -- empty on success -- rather than
+// log.Fatal-ing the host process.
+//
+//export FinalizePhase2
+func FinalizePhase2(handle string) (*C.char, *C.char, *C.char) {
+	phase2CacheMu.Lock()
+	state, ok := phase2Cache[handle]
+	phase2CacheMu.Unlock()
+	if !ok {
+		log.Fatal(fmt.Errorf("ceremony: no phase-2 state cached under handle %q", handle))
+	}
+
+	pk, vk, err := ceremony.Phase2Finalize(state)
+	if err != nil {
+		return C.CString(""), C.CString(""), C.CString(err.Error())
+	}
+
+	var serializedPK bytes.Buffer
+	pk.WriteTo(&serializedPK)
+
+	var serializedVK bytes.Buffer
+	vk.WriteTo(&serializedVK)
+
+	return C.CString(hex.EncodeToString(serializedPK.Bytes())), C.CString(hex.EncodeToString(serializedVK.Bytes())), C.CString("")
+}
+
+func cachePhase2State(state *ceremony.Phase2State) string {
+	phase2CacheMu.Lock()
+	defer phase2CacheMu.Unlock()
+	phase2Counter++
+	handle := fmt.Sprintf("phase2-%d", phase2Counter)
+	phase2Cache[handle] = state
+	return handle
+}
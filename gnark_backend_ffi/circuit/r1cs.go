@@ -0,0 +1,334 @@
+package circuit
+
+import (
+	"fmt"
+	"reflect"
+
+	"gnark_backend_ffi/structs"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	cs_bls12377 "github.com/consensys/gnark/constraint/bls12-377"
+	cs_bls12381 "github.com/consensys/gnark/constraint/bls12-381"
+	cs_bls24315 "github.com/consensys/gnark/constraint/bls24-315"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	cs_bw6761 "github.com/consensys/gnark/constraint/bw6-761"
+)
+
+// Build dispatches on r.Curve to build the curve-specific R1CS and witness
+// for a Noir-derived RawR1CS. It is the single place every Groth16 //export
+// entry point routes through, so adding a curve means adding one case here plus one
+// buildR1CS<Curve> below.
+//
+// Build returns curveID alongside the built constraint.ConstraintSystem
+// because that interface (unlike the concrete cs_bn254.R1CS etc. types
+// buildR1CS<Curve> actually constructs) exposes neither CurveID() nor
+// AddConstraint()/MakeTerm() in the gnark vintage this module otherwise
+// targets. Every caller that used to call r1cs.CurveID() on the returned
+// interface value now takes curveID as a second return instead.
+func Build(r structs.RawR1CS) (constraint.ConstraintSystem, ecc.ID, witness.Witness, error) {
+	curveID, err := structs.CurveID(r.Curve)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	switch curveID {
+	case ecc.BN254:
+		r1cs, w, err := buildR1CSBN254(r)
+		return r1cs, curveID, w, err
+	case ecc.BLS12_381:
+		r1cs, w, err := buildR1CSBLS12381(r)
+		return r1cs, curveID, w, err
+	case ecc.BLS12_377:
+		r1cs, w, err := buildR1CSBLS12377(r)
+		return r1cs, curveID, w, err
+	case ecc.BW6_761:
+		r1cs, w, err := buildR1CSBW6761(r)
+		return r1cs, curveID, w, err
+	case ecc.BLS24_315:
+		r1cs, w, err := buildR1CSBLS24315(r)
+		return r1cs, curveID, w, err
+	default:
+		return nil, 0, nil, &structs.UnsupportedCurveError{Curve: r.Curve}
+	}
+}
+
+// buildWitnesses assembles a witness.Witness from already-decoded public and
+// private variables. It takes curveID directly (rather than reading it off
+// r1cs) since constraint.ConstraintSystem doesn't expose CurveID(); the
+// values themselves only need to satisfy the untyped `any` that
+// witness.Fill streams them through.
+func buildWitnesses(curveID ecc.ID, publicVariables []any, privateVariables []any, nPublicVariables int, nPrivateVariables int) (witness.Witness, error) {
+	witnessValues := make(chan any)
+
+	go func() {
+		defer close(witnessValues)
+		for _, publicVariable := range publicVariables {
+			witnessValues <- publicVariable
+		}
+		for _, privateVariable := range privateVariables {
+			witnessValues <- privateVariable
+		}
+	}()
+
+	w, err := witness.New(curveID.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.Fill(nPublicVariables, nPrivateVariables, witnessValues); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// r1csOps is the subset of a concrete per-curve R1CS type's method set
+// (cs_bn254.R1CS, cs_bls12381.R1CS, ...) buildGates needs, captured as bound
+// method values by each buildR1CS<Curve> below instead of named as a shared
+// interface type: constraint.ConstraintSystem doesn't expose AddConstraint/
+// FromInterface/MakeTerm in this gnark vintage (see Build's doc comment),
+// and AddConstraint's own return value is never read anywhere in this
+// package, so addConstraint is wrapped in a closure that discards it rather
+// than guessed at here.
+type r1csOps struct {
+	addPublicVariable   func(name string) int
+	addSecretVariable   func(name string) int
+	addInternalVariable func() int
+	addConstraint       func(c constraint.R1C)
+	fromInterface       func(i interface{}) constraint.Coeff
+	makeTerm            func(coeff *constraint.Coeff, variableID int) constraint.Term
+}
+
+// anySlice flattens a decoded fr_<curve>.Vector (returned by
+// structs.DeserializeFelts as `any`, since its concrete element type
+// differs per curve) into a []any of its elements, so buildGates can range
+// over one curve-agnostic value vector instead of every buildR1CS<Curve>
+// repeating its own `values.(fr_<curve>.Vector)` assertion.
+func anySlice(v any) []any {
+	rv := reflect.ValueOf(v)
+	out := make([]any, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return out
+}
+
+// buildGates translates a Noir-derived RawR1CS's gates into R1CS constraints
+// against ops, the curve-specific R1CS buildR1CS<Curve> already constructed,
+// and assembles the resulting witness. It is the one copy of the
+// translation every buildR1CS<Curve> used to duplicate (each ~90 lines,
+// differing only in which cs_<curve>/fr_<curve> package they closed over) --
+// collapsed here so a gate-translation bug fix only needs to land in one
+// place.
+//
+// Each mul_term gets its own auxiliary constraint binding an internal
+// variable to its product, the gate's linear side sums those products with
+// the add_terms and constant_term, and the whole thing is enforced as one
+// L·1=O constraint against the ONE wire.
+//
+// See r1cs_test.go for table-driven coverage of pure-linear, pure-mul, and
+// mixed gates.
+func buildGates(r structs.RawR1CS, curveID ecc.ID, values []any, ops r1csOps) (witness.Witness, error) {
+	// ONE must be the very first public variable added: gnark's solver
+	// always treats wire 0 as the constant-1 wire (solution.values[0] is
+	// hardcoded to 1 and the supplied witness starts at wire 1, see
+	// constraint/bn254/r1cs.go's Solve), regardless of which public
+	// variable we ourselves added first. Adding it after the per-value
+	// loop below would hand wire 0 to the first public input instead and
+	// silently force that input's value to 1.
+	ONE := ops.addPublicVariable("1")
+	COEFFICIENT_ONE := ops.fromInterface(1)
+
+	nPublicVariables := 0
+	nPrivateVariables := 0
+	var allVariableIndices []int
+	var publicVariables []any
+	var privateVariables []any
+	for i, value := range values {
+		i++
+		if isPublicInput(uint32(i), r.PublicInputs) {
+			allVariableIndices = append(allVariableIndices, ops.addPublicVariable(fmt.Sprintf("public_%d", i)))
+			publicVariables = append(publicVariables, value)
+			nPublicVariables++
+		} else {
+			allVariableIndices = append(allVariableIndices, ops.addSecretVariable(fmt.Sprintf("secret_%d", i)))
+			privateVariables = append(privateVariables, value)
+			nPrivateVariables++
+		}
+	}
+	for _, gate := range r.Gates {
+		var terms constraint.LinearExpression
+
+		for _, mulTerm := range gate.MulTerms {
+			coefficient, err := structs.DeserializeFelt(mulTerm.Coefficient, r.Curve)
+			if err != nil {
+				return nil, err
+			}
+			coeff := ops.fromInterface(coefficient)
+
+			multiplicand := allVariableIndices[mulTerm.Multiplicand]
+			multiplier := allVariableIndices[mulTerm.Multiplier]
+			product := ops.addInternalVariable()
+
+			// (coeff * multiplicand) * multiplier = product
+			ops.addConstraint(constraint.R1C{
+				L: constraint.LinearExpression{ops.makeTerm(&coeff, multiplicand)},
+				R: constraint.LinearExpression{ops.makeTerm(&COEFFICIENT_ONE, multiplier)},
+				O: constraint.LinearExpression{ops.makeTerm(&COEFFICIENT_ONE, product)},
+			})
+
+			terms = append(terms, ops.makeTerm(&COEFFICIENT_ONE, product))
+		}
+
+		for _, addTerm := range gate.AddTerms {
+			coefficient, err := structs.DeserializeFelt(addTerm.Coefficient, r.Curve)
+			if err != nil {
+				return nil, err
+			}
+			coeff := ops.fromInterface(coefficient)
+
+			sumVariable := allVariableIndices[addTerm.Sum]
+
+			terms = append(terms, ops.makeTerm(&coeff, sumVariable))
+		}
+
+		constantTerm, err := structs.DeserializeFelt(gate.ConstantTerm, r.Curve)
+		if err != nil {
+			return nil, err
+		}
+		constant := ops.fromInterface(constantTerm)
+		terms = append(terms, ops.makeTerm(&constant, ONE))
+
+		// ONE * (sum t_i + sum coeff*sum_var + constant) = 0
+		ops.addConstraint(
+			constraint.R1C{
+				L: constraint.LinearExpression{ops.makeTerm(&COEFFICIENT_ONE, ONE)},
+				R: terms,
+				O: constraint.LinearExpression{},
+			},
+		)
+	}
+
+	return buildWitnesses(curveID, publicVariables, privateVariables, nPublicVariables, nPrivateVariables)
+}
+
+func buildR1CSBN254(r structs.RawR1CS) (constraint.ConstraintSystem, witness.Witness, error) {
+	r1cs := cs_bn254.NewR1CS(int(r.NumConstraints))
+
+	values, err := structs.DeserializeFelts(r.Values, r.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w, err := buildGates(r, ecc.BN254, anySlice(values), r1csOps{
+		addPublicVariable:   r1cs.AddPublicVariable,
+		addSecretVariable:   r1cs.AddSecretVariable,
+		addInternalVariable: r1cs.AddInternalVariable,
+		addConstraint:       func(c constraint.R1C) { r1cs.AddConstraint(c) },
+		fromInterface:       r1cs.FromInterface,
+		makeTerm:            r1cs.MakeTerm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r1cs, w, nil
+}
+
+func buildR1CSBLS12381(r structs.RawR1CS) (constraint.ConstraintSystem, witness.Witness, error) {
+	r1cs := cs_bls12381.NewR1CS(int(r.NumConstraints))
+
+	values, err := structs.DeserializeFelts(r.Values, r.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w, err := buildGates(r, ecc.BLS12_381, anySlice(values), r1csOps{
+		addPublicVariable:   r1cs.AddPublicVariable,
+		addSecretVariable:   r1cs.AddSecretVariable,
+		addInternalVariable: r1cs.AddInternalVariable,
+		addConstraint:       func(c constraint.R1C) { r1cs.AddConstraint(c) },
+		fromInterface:       r1cs.FromInterface,
+		makeTerm:            r1cs.MakeTerm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r1cs, w, nil
+}
+
+func buildR1CSBLS12377(r structs.RawR1CS) (constraint.ConstraintSystem, witness.Witness, error) {
+	r1cs := cs_bls12377.NewR1CS(int(r.NumConstraints))
+
+	values, err := structs.DeserializeFelts(r.Values, r.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w, err := buildGates(r, ecc.BLS12_377, anySlice(values), r1csOps{
+		addPublicVariable:   r1cs.AddPublicVariable,
+		addSecretVariable:   r1cs.AddSecretVariable,
+		addInternalVariable: r1cs.AddInternalVariable,
+		addConstraint:       func(c constraint.R1C) { r1cs.AddConstraint(c) },
+		fromInterface:       r1cs.FromInterface,
+		makeTerm:            r1cs.MakeTerm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r1cs, w, nil
+}
+
+func buildR1CSBW6761(r structs.RawR1CS) (constraint.ConstraintSystem, witness.Witness, error) {
+	r1cs := cs_bw6761.NewR1CS(int(r.NumConstraints))
+
+	values, err := structs.DeserializeFelts(r.Values, r.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w, err := buildGates(r, ecc.BW6_761, anySlice(values), r1csOps{
+		addPublicVariable:   r1cs.AddPublicVariable,
+		addSecretVariable:   r1cs.AddSecretVariable,
+		addInternalVariable: r1cs.AddInternalVariable,
+		addConstraint:       func(c constraint.R1C) { r1cs.AddConstraint(c) },
+		fromInterface:       r1cs.FromInterface,
+		makeTerm:            r1cs.MakeTerm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r1cs, w, nil
+}
+
+func buildR1CSBLS24315(r structs.RawR1CS) (constraint.ConstraintSystem, witness.Witness, error) {
+	r1cs := cs_bls24315.NewR1CS(int(r.NumConstraints))
+
+	values, err := structs.DeserializeFelts(r.Values, r.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w, err := buildGates(r, ecc.BLS24_315, anySlice(values), r1csOps{
+		addPublicVariable:   r1cs.AddPublicVariable,
+		addSecretVariable:   r1cs.AddSecretVariable,
+		addInternalVariable: r1cs.AddInternalVariable,
+		addConstraint:       func(c constraint.R1C) { r1cs.AddConstraint(c) },
+		fromInterface:       r1cs.FromInterface,
+		makeTerm:            r1cs.MakeTerm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r1cs, w, nil
+}
+
+func isPublicInput(i uint32, publicInputs []uint32) bool {
+	for _, publicInput := range publicInputs {
+		if i == publicInput {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package circuit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"gnark_backend_ffi/structs"
+
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+// feltHex hex-encodes v's canonical BN254 field element representation, the
+// same coefficient/value encoding RawR1CS and structs.DeserializeFelt read.
+func feltHex(v int64) string {
+	var felt fr_bn254.Element
+	felt.SetInt64(v)
+	return hex.EncodeToString(felt.Marshal())
+}
+
+// valuesHex encodes vs as the length-prefixed BN254 felt vector
+// structs.DeserializeFelts expects in RawR1CS.Values, via the same
+// EncodeFeltsTo codec DeserializeFelts decodes with.
+func valuesHex(vs ...int64) string {
+	felts := make(fr_bn254.Vector, len(vs))
+	for i, v := range vs {
+		felts[i].SetInt64(v)
+	}
+	var buf bytes.Buffer
+	if err := structs.EncodeFeltsTo(&buf, felts); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf.Bytes())
+}
+
+// proveAndVerify builds r via Build, then runs it through a real
+// groth16.Setup/Prove/Verify round trip, the same sequence main.go's
+// ProveWithMeta/VerifyWithMeta use. It fails the test if any step errors or
+// verification rejects a satisfying witness.
+func proveAndVerify(t *testing.T, r structs.RawR1CS) {
+	t.Helper()
+
+	r1cs, _, w, err := Build(r)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	pk, vk, err := groth16.Setup(r1cs)
+	if err != nil {
+		t.Fatalf("groth16.Setup: %v", err)
+	}
+
+	proof, err := groth16.Prove(r1cs, pk, w)
+	if err != nil {
+		t.Fatalf("groth16.Prove: %v", err)
+	}
+
+	publicWitness, err := w.Public()
+	if err != nil {
+		t.Fatalf("witness.Public: %v", err)
+	}
+
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		t.Fatalf("groth16.Verify: %v", err)
+	}
+}
+
+// TestBuildGatesPureLinear exercises a gate with only add_terms and a
+// constant_term: Y - X1 - X2 = 0, i.e. Y = X1 + X2. Values is [Y, X1, X2];
+// Sum indexes directly into Values (0-based), same as buildGates reads it --
+// unlike PublicInputs, which is matched against buildGates' own 1-based
+// loop counter.
+func TestBuildGatesPureLinear(t *testing.T) {
+	r := structs.RawR1CS{
+		Curve: structs.DefaultCurve,
+		Gates: []structs.RawGate{{
+			AddTerms: []structs.AddTerm{
+				{Coefficient: feltHex(1), Sum: 0},
+				{Coefficient: feltHex(-1), Sum: 1},
+				{Coefficient: feltHex(-1), Sum: 2},
+			},
+			ConstantTerm: feltHex(0),
+		}},
+		PublicInputs:   []uint32{1},
+		Values:         valuesHex(5, 2, 3), // Y=5, X1=2, X2=3
+		NumVariables:   3,
+		NumConstraints: 1,
+	}
+	proveAndVerify(t, r)
+}
+
+// TestBuildGatesPureMul exercises a gate with a single mul_term, plus the one
+// add_term and constant_term that tie its product to Y: X1*X2 - Y = 0, i.e.
+// Y = X1 * X2. Values is [Y, X1, X2];
+// Multiplicand/Multiplier/Sum are 0-based indices into Values.
+func TestBuildGatesPureMul(t *testing.T) {
+	r := structs.RawR1CS{
+		Curve: structs.DefaultCurve,
+		Gates: []structs.RawGate{{
+			MulTerms: []structs.MulTerm{
+				{Coefficient: feltHex(1), Multiplicand: 1, Multiplier: 2},
+			},
+			AddTerms: []structs.AddTerm{
+				{Coefficient: feltHex(-1), Sum: 0},
+			},
+			ConstantTerm: feltHex(0),
+		}},
+		PublicInputs:   []uint32{1},
+		Values:         valuesHex(6, 2, 3), // Y=6, X1=2, X2=3
+		NumVariables:   3,
+		NumConstraints: 1,
+	}
+	proveAndVerify(t, r)
+}
+
+// TestBuildGatesMixed round-trips the x^3+x+5 == Y example circuit
+// through the Noir JSON gate format: v0 = X*X,
+// v1 = v0*X, Y = v1 + X + 5. Values is [Y, X, v0, v1] (0-based indices
+// 0-3), the indices Multiplicand/Multiplier/Sum below index into directly.
+func TestBuildGatesMixed(t *testing.T) {
+	const x, y = 3, 35 // 3^3 + 3 + 5 == 35
+	v0 := x * x
+	v1 := v0 * x
+
+	r := structs.RawR1CS{
+		Curve: structs.DefaultCurve,
+		Gates: []structs.RawGate{
+			{
+				// v0 == X * X
+				MulTerms: []structs.MulTerm{
+					{Coefficient: feltHex(1), Multiplicand: 1, Multiplier: 1},
+				},
+				AddTerms: []structs.AddTerm{
+					{Coefficient: feltHex(-1), Sum: 2},
+				},
+				ConstantTerm: feltHex(0),
+			},
+			{
+				// v1 == v0 * X
+				MulTerms: []structs.MulTerm{
+					{Coefficient: feltHex(1), Multiplicand: 2, Multiplier: 1},
+				},
+				AddTerms: []structs.AddTerm{
+					{Coefficient: feltHex(-1), Sum: 3},
+				},
+				ConstantTerm: feltHex(0),
+			},
+			{
+				// Y == v1 + X + 5
+				AddTerms: []structs.AddTerm{
+					{Coefficient: feltHex(-1), Sum: 0},
+					{Coefficient: feltHex(1), Sum: 3},
+					{Coefficient: feltHex(1), Sum: 1},
+				},
+				ConstantTerm: feltHex(5),
+			},
+		},
+		PublicInputs:   []uint32{1},
+		Values:         valuesHex(y, x, int64(v0), int64(v1)),
+		NumVariables:   4,
+		NumConstraints: 3,
+	}
+	proveAndVerify(t, r)
+}
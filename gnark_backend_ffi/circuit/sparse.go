@@ -0,0 +1,287 @@
+package circuit
+
+import (
+	"fmt"
+
+	"gnark_backend_ffi/structs"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fr_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	cs_bls12377 "github.com/consensys/gnark/constraint/bls12-377"
+	cs_bls12381 "github.com/consensys/gnark/constraint/bls12-381"
+	cs_bls24315 "github.com/consensys/gnark/constraint/bls24-315"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	cs_bw6761 "github.com/consensys/gnark/constraint/bw6-761"
+)
+
+// BuildSparse is Build's PLONK counterpart: it dispatches on r.Curve to build
+// a constraint.SparseR1CS instead of a dense R1CS, since plonk.Setup/Prove/
+// Verify only accept the former. Every PLONK //export entry point routes
+// through this the same way Build is the one entry point for Groth16.
+func BuildSparse(r structs.RawR1CS) (constraint.ConstraintSystem, ecc.ID, witness.Witness, error) {
+	curveID, err := structs.CurveID(r.Curve)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	switch curveID {
+	case ecc.BN254:
+		scs, w, err := buildSparseR1CSBN254(r)
+		return scs, curveID, w, err
+	case ecc.BLS12_381:
+		scs, w, err := buildSparseR1CSBLS12381(r)
+		return scs, curveID, w, err
+	case ecc.BLS12_377:
+		scs, w, err := buildSparseR1CSBLS12377(r)
+		return scs, curveID, w, err
+	case ecc.BW6_761:
+		scs, w, err := buildSparseR1CSBW6761(r)
+		return scs, curveID, w, err
+	case ecc.BLS24_315:
+		scs, w, err := buildSparseR1CSBLS24315(r)
+		return scs, curveID, w, err
+	default:
+		return nil, 0, nil, &structs.UnsupportedCurveError{Curve: r.Curve}
+	}
+}
+
+// scsOps is r1csOps's SparseR1CS counterpart: the subset of a concrete
+// per-curve SparseR1CS type's method set buildSparseGates needs, captured as
+// bound method values for the same reason r1csOps is -- this gnark vintage's
+// constraint.ConstraintSystem doesn't expose AddConstraint/FromInterface/
+// MakeTerm.
+type scsOps struct {
+	addPublicVariable   func(name string) int
+	addSecretVariable   func(name string) int
+	addInternalVariable func() int
+	addConstraint       func(c constraint.SparseR1C)
+	fromInterface       func(i interface{}) constraint.Coeff
+	makeTerm            func(coeff *constraint.Coeff, variableID int) constraint.Term
+}
+
+// buildSparseGates translates a Noir-derived RawR1CS's gates into PLONK's
+// custom-gate form qL.a + qR.b + qO.c + qM.a.b + qC = 0, against ops, the
+// curve-specific SparseR1CS buildSparseR1CS<Curve> already constructed. It is
+// the one copy of that translation every buildSparseR1CS<Curve> shares, the
+// same way buildGates is shared by buildR1CS<Curve>.
+//
+// A PLONK gate has only one multiplication slot, unlike an R1CS constraint's
+// L*R=O, so each mul_term needs its own "pure multiplication" gate binding a
+// fresh internal variable to the product; the add_terms and constant_term
+// are then folded into a running accumulator one "pure addition" gate at a
+// time, closing with a gate that ties the accumulator to the ONE wire.
+func buildSparseGates(r structs.RawR1CS, curveID ecc.ID, values []any, one any, zero any, ops scsOps) (witness.Witness, error) {
+	// ONE and ZERO must be added before any secret variable, not after:
+	// gnark's constraint.System.AddSecretVariable bakes the current public
+	// variable count into the secret wire index it returns at call time, so
+	// adding ONE/ZERO here after the per-value loop below would collide
+	// their wires with ones the loop already handed out to secret inputs.
+	// This is a different hazard than buildGates' "ONE must be first"
+	// comment (that one's about the dense R1CS solver hardcoding wire 0 to
+	// 1), but the fix is the same shape: reserve these wires up front.
+	ONE := ops.addPublicVariable("1")
+	ZERO := ops.addPublicVariable("0")
+	publicVariables := []any{one, zero}
+	nPublicVariables := 2
+
+	nPrivateVariables := 0
+	var allVariableIndices []int
+	var privateVariables []any
+	for i, value := range values {
+		i++
+		if isPublicInput(uint32(i), r.PublicInputs) {
+			allVariableIndices = append(allVariableIndices, ops.addPublicVariable(fmt.Sprintf("public_%d", i)))
+			publicVariables = append(publicVariables, value)
+			nPublicVariables++
+		} else {
+			allVariableIndices = append(allVariableIndices, ops.addSecretVariable(fmt.Sprintf("secret_%d", i)))
+			privateVariables = append(privateVariables, value)
+			nPrivateVariables++
+		}
+	}
+
+	COEFFICIENT_ONE := ops.fromInterface(1)
+	COEFFICIENT_MINUS_ONE := ops.fromInterface(-1)
+
+	for _, gate := range r.Gates {
+		acc := ZERO
+
+		for _, mulTerm := range gate.MulTerms {
+			coefficient, err := structs.DeserializeFelt(mulTerm.Coefficient, r.Curve)
+			if err != nil {
+				return nil, err
+			}
+			qM := ops.fromInterface(coefficient)
+
+			a := allVariableIndices[mulTerm.Multiplicand]
+			b := allVariableIndices[mulTerm.Multiplier]
+			t := ops.addInternalVariable()
+
+			// qM*a*b - t = 0
+			ops.addConstraint(constraint.SparseR1C{
+				M: [2]constraint.Term{ops.makeTerm(&qM, a), ops.makeTerm(&COEFFICIENT_ONE, b)},
+				O: ops.makeTerm(&COEFFICIENT_MINUS_ONE, t),
+			})
+
+			// acc + t - next = 0
+			next := ops.addInternalVariable()
+			ops.addConstraint(constraint.SparseR1C{
+				L: ops.makeTerm(&COEFFICIENT_ONE, acc),
+				R: ops.makeTerm(&COEFFICIENT_ONE, t),
+				O: ops.makeTerm(&COEFFICIENT_MINUS_ONE, next),
+			})
+			acc = next
+		}
+
+		for _, addTerm := range gate.AddTerms {
+			coefficient, err := structs.DeserializeFelt(addTerm.Coefficient, r.Curve)
+			if err != nil {
+				return nil, err
+			}
+			qR := ops.fromInterface(coefficient)
+
+			// acc + qR*sum_var - next = 0
+			next := ops.addInternalVariable()
+			ops.addConstraint(constraint.SparseR1C{
+				L: ops.makeTerm(&COEFFICIENT_ONE, acc),
+				R: ops.makeTerm(&qR, allVariableIndices[addTerm.Sum]),
+				O: ops.makeTerm(&COEFFICIENT_MINUS_ONE, next),
+			})
+			acc = next
+		}
+
+		constantTerm, err := structs.DeserializeFelt(gate.ConstantTerm, r.Curve)
+		if err != nil {
+			return nil, err
+		}
+		qC := ops.fromInterface(constantTerm)
+		// acc + qC*ONE = 0
+		ops.addConstraint(constraint.SparseR1C{
+			L: ops.makeTerm(&COEFFICIENT_ONE, acc),
+			R: ops.makeTerm(&qC, ONE),
+		})
+	}
+
+	return buildWitnesses(curveID, publicVariables, privateVariables, nPublicVariables, nPrivateVariables)
+}
+
+func buildSparseR1CSBN254(r structs.RawR1CS) (constraint.ConstraintSystem, witness.Witness, error) {
+	scs := cs_bn254.NewSparseR1CS(int(r.NumConstraints))
+
+	values, err := structs.DeserializeFelts(r.Values, r.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var zero fr_bn254.Element
+	w, err := buildSparseGates(r, ecc.BN254, anySlice(values), fr_bn254.One(), zero, scsOps{
+		addPublicVariable:   scs.AddPublicVariable,
+		addSecretVariable:   scs.AddSecretVariable,
+		addInternalVariable: scs.AddInternalVariable,
+		addConstraint:       func(c constraint.SparseR1C) { scs.AddConstraint(c) },
+		fromInterface:       scs.FromInterface,
+		makeTerm:            scs.MakeTerm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return scs, w, nil
+}
+
+func buildSparseR1CSBLS12381(r structs.RawR1CS) (constraint.ConstraintSystem, witness.Witness, error) {
+	scs := cs_bls12381.NewSparseR1CS(int(r.NumConstraints))
+
+	values, err := structs.DeserializeFelts(r.Values, r.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var zero fr_bls12381.Element
+	w, err := buildSparseGates(r, ecc.BLS12_381, anySlice(values), fr_bls12381.One(), zero, scsOps{
+		addPublicVariable:   scs.AddPublicVariable,
+		addSecretVariable:   scs.AddSecretVariable,
+		addInternalVariable: scs.AddInternalVariable,
+		addConstraint:       func(c constraint.SparseR1C) { scs.AddConstraint(c) },
+		fromInterface:       scs.FromInterface,
+		makeTerm:            scs.MakeTerm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return scs, w, nil
+}
+
+func buildSparseR1CSBLS12377(r structs.RawR1CS) (constraint.ConstraintSystem, witness.Witness, error) {
+	scs := cs_bls12377.NewSparseR1CS(int(r.NumConstraints))
+
+	values, err := structs.DeserializeFelts(r.Values, r.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var zero fr_bls12377.Element
+	w, err := buildSparseGates(r, ecc.BLS12_377, anySlice(values), fr_bls12377.One(), zero, scsOps{
+		addPublicVariable:   scs.AddPublicVariable,
+		addSecretVariable:   scs.AddSecretVariable,
+		addInternalVariable: scs.AddInternalVariable,
+		addConstraint:       func(c constraint.SparseR1C) { scs.AddConstraint(c) },
+		fromInterface:       scs.FromInterface,
+		makeTerm:            scs.MakeTerm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return scs, w, nil
+}
+
+func buildSparseR1CSBW6761(r structs.RawR1CS) (constraint.ConstraintSystem, witness.Witness, error) {
+	scs := cs_bw6761.NewSparseR1CS(int(r.NumConstraints))
+
+	values, err := structs.DeserializeFelts(r.Values, r.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var zero fr_bw6761.Element
+	w, err := buildSparseGates(r, ecc.BW6_761, anySlice(values), fr_bw6761.One(), zero, scsOps{
+		addPublicVariable:   scs.AddPublicVariable,
+		addSecretVariable:   scs.AddSecretVariable,
+		addInternalVariable: scs.AddInternalVariable,
+		addConstraint:       func(c constraint.SparseR1C) { scs.AddConstraint(c) },
+		fromInterface:       scs.FromInterface,
+		makeTerm:            scs.MakeTerm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return scs, w, nil
+}
+
+func buildSparseR1CSBLS24315(r structs.RawR1CS) (constraint.ConstraintSystem, witness.Witness, error) {
+	scs := cs_bls24315.NewSparseR1CS(int(r.NumConstraints))
+
+	values, err := structs.DeserializeFelts(r.Values, r.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var zero fr_bls24315.Element
+	w, err := buildSparseGates(r, ecc.BLS24_315, anySlice(values), fr_bls24315.One(), zero, scsOps{
+		addPublicVariable:   scs.AddPublicVariable,
+		addSecretVariable:   scs.AddSecretVariable,
+		addInternalVariable: scs.AddInternalVariable,
+		addConstraint:       func(c constraint.SparseR1C) { scs.AddConstraint(c) },
+		fromInterface:       scs.FromInterface,
+		makeTerm:            scs.MakeTerm,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return scs, w, nil
+}
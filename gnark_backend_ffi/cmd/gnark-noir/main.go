@@ -0,0 +1,298 @@
+// Command gnark-noir is a CLI front-end for the Groth16 flow the FFI in
+// gnark_backend_ffi exposes to Rust: compile a circuit, run trusted setup,
+// prove, and verify, each as its own subcommand reading/writing the same
+// hex-encoded JSON artifacts the FFI speaks (RawR1CS, proving/verifying
+// keys, proofs, public witnesses). Where the FFI bundles these steps
+// together for a single cross-language call, this binary keeps them
+// separate so a user can persist a proving key across runs, hand a proof to
+// someone else, or verify one without ever touching the private witness.
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"gnark_backend_ffi/circuit"
+	"gnark_backend_ffi/structs"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "compile":
+		err = runCompile(os.Args[2:])
+	case "setup":
+		err = runSetup(os.Args[2:])
+	case "prove":
+		err = runProve(os.Args[2:])
+	case "verify":
+		err = runVerify(os.Args[2:], false)
+	case "verify-invalid":
+		err = runVerify(os.Args[2:], true)
+	case "-h", "-help", "--help", "help":
+		printUsage()
+		return
+	default:
+		printUsage()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gnark-noir %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, `usage: gnark-noir <command> [flags]
+
+commands:
+  compile         parse a circuit and its witness, report constraint stats
+  setup           run Groth16 trusted setup, writing a proving and verifying key
+  prove           produce a proof and its public witness from a circuit and witness
+  verify          check a proof against a verifying key and public witness
+  verify-invalid  like verify, but exits 0 only if verification fails
+
+run "gnark-noir <command> -h" for a command's flags`)
+}
+
+// loadRawR1CS reads circuitPath into a structs.RawR1CS and, if witnessPath is
+// non-empty, overrides its Values field with the hex blob at witnessPath.
+// Circuit shape and witness values are kept in separate files so a verifier
+// never needs the file that contains the private inputs.
+func loadRawR1CS(circuitPath string, witnessPath string, curveOverride string) (structs.RawR1CS, error) {
+	var r structs.RawR1CS
+
+	circuitBytes, err := os.ReadFile(circuitPath)
+	if err != nil {
+		return r, fmt.Errorf("reading circuit: %w", err)
+	}
+	if err := json.Unmarshal(circuitBytes, &r); err != nil {
+		return r, fmt.Errorf("parsing circuit: %w", err)
+	}
+
+	if witnessPath != "" {
+		witnessBytes, err := os.ReadFile(witnessPath)
+		if err != nil {
+			return r, fmt.Errorf("reading witness: %w", err)
+		}
+		r.Values = string(bytes.TrimSpace(witnessBytes))
+	}
+
+	if curveOverride != "" {
+		r.Curve = curveOverride
+	}
+
+	return r, nil
+}
+
+func runCompile(args []string) error {
+	fs := flag.NewFlagSet("compile", flag.ExitOnError)
+	circuitPath := fs.String("circuit", "", "path to the circuit JSON (required)")
+	witnessPath := fs.String("witness", "", "path to the hex-encoded witness values (required)")
+	curve := fs.String("curve", "", "curve override, defaults to the circuit's own \"curve\" field")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *circuitPath == "" || *witnessPath == "" {
+		return fmt.Errorf("-circuit and -witness are required")
+	}
+
+	r, err := loadRawR1CS(*circuitPath, *witnessPath, *curve)
+	if err != nil {
+		return err
+	}
+
+	// Variable allocation is driven by the witness values, not just the
+	// circuit shape (see circuit.Build), so a witness-free "compile" isn't
+	// possible with this module's current R1CS builder: this step validates
+	// both together and reports the resulting circuit's stats.
+	r1cs, _, _, err := circuit.Build(r)
+	if err != nil {
+		return fmt.Errorf("building circuit: %w", err)
+	}
+
+	fmt.Printf("curve: %s\n", r.CurveOrDefault())
+	fmt.Printf("constraints: %d\n", r1cs.GetNbConstraints())
+	fmt.Printf("public variables: %d\n", r1cs.GetNbPublicVariables())
+	fmt.Printf("secret variables: %d\n", r1cs.GetNbSecretVariables())
+	fmt.Printf("internal variables: %d\n", r1cs.GetNbInternalVariables())
+
+	return nil
+}
+
+func runSetup(args []string) error {
+	fs := flag.NewFlagSet("setup", flag.ExitOnError)
+	circuitPath := fs.String("circuit", "", "path to the circuit JSON (required)")
+	witnessPath := fs.String("witness", "", "path to the hex-encoded witness values (required)")
+	curve := fs.String("curve", "", "curve override, defaults to the circuit's own \"curve\" field")
+	pkPath := fs.String("pk", "", "output path for the hex-encoded proving key (required)")
+	vkPath := fs.String("vk", "", "output path for the hex-encoded verifying key (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *circuitPath == "" || *witnessPath == "" || *pkPath == "" || *vkPath == "" {
+		return fmt.Errorf("-circuit, -witness, -pk, and -vk are required")
+	}
+
+	r, err := loadRawR1CS(*circuitPath, *witnessPath, *curve)
+	if err != nil {
+		return err
+	}
+
+	r1cs, _, _, err := circuit.Build(r)
+	if err != nil {
+		return fmt.Errorf("building circuit: %w", err)
+	}
+
+	pk, vk, err := groth16.Setup(r1cs)
+	if err != nil {
+		return fmt.Errorf("running setup: %w", err)
+	}
+
+	if err := writeHexFile(*pkPath, pk); err != nil {
+		return fmt.Errorf("writing proving key: %w", err)
+	}
+	if err := writeHexFile(*vkPath, vk); err != nil {
+		return fmt.Errorf("writing verifying key: %w", err)
+	}
+
+	return nil
+}
+
+func runProve(args []string) error {
+	fs := flag.NewFlagSet("prove", flag.ExitOnError)
+	circuitPath := fs.String("circuit", "", "path to the circuit JSON (required)")
+	witnessPath := fs.String("witness", "", "path to the hex-encoded witness values (required)")
+	curve := fs.String("curve", "", "curve override, defaults to the circuit's own \"curve\" field")
+	pkPath := fs.String("pk", "", "path to the hex-encoded proving key (required)")
+	proofPath := fs.String("proof", "", "output path for the hex-encoded proof (required)")
+	publicPath := fs.String("public", "", "output path for the hex-encoded public witness (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *circuitPath == "" || *witnessPath == "" || *pkPath == "" || *proofPath == "" || *publicPath == "" {
+		return fmt.Errorf("-circuit, -witness, -pk, -proof, and -public are required")
+	}
+
+	r, err := loadRawR1CS(*circuitPath, *witnessPath, *curve)
+	if err != nil {
+		return err
+	}
+
+	r1cs, curveID, w, err := circuit.Build(r)
+	if err != nil {
+		return fmt.Errorf("building circuit: %w", err)
+	}
+
+	pk := groth16.NewProvingKey(curveID)
+	if err := readHexFile(*pkPath, pk); err != nil {
+		return fmt.Errorf("reading proving key: %w", err)
+	}
+
+	proof, err := groth16.Prove(r1cs, pk, w)
+	if err != nil {
+		return fmt.Errorf("proving: %w", err)
+	}
+
+	publicWitness, err := w.Public()
+	if err != nil {
+		return fmt.Errorf("extracting public witness: %w", err)
+	}
+
+	if err := writeHexFile(*proofPath, proof); err != nil {
+		return fmt.Errorf("writing proof: %w", err)
+	}
+	if err := writeHexFile(*publicPath, publicWitness); err != nil {
+		return fmt.Errorf("writing public witness: %w", err)
+	}
+
+	return nil
+}
+
+func runVerify(args []string, expectInvalid bool) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	curve := fs.String("curve", structs.DefaultCurve, "curve the proof/vk/public witness were produced for")
+	vkPath := fs.String("vk", "", "path to the hex-encoded verifying key (required)")
+	proofPath := fs.String("proof", "", "path to the hex-encoded proof (required)")
+	publicPath := fs.String("public", "", "path to the hex-encoded public witness (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *vkPath == "" || *proofPath == "" || *publicPath == "" {
+		return fmt.Errorf("-vk, -proof, and -public are required")
+	}
+
+	curveID, err := structs.CurveID(*curve)
+	if err != nil {
+		return err
+	}
+
+	vk := groth16.NewVerifyingKey(curveID)
+	if err := readHexFile(*vkPath, vk); err != nil {
+		return fmt.Errorf("reading verifying key: %w", err)
+	}
+
+	proof := groth16.NewProof(curveID)
+	if err := readHexFile(*proofPath, proof); err != nil {
+		return fmt.Errorf("reading proof: %w", err)
+	}
+
+	publicWitness, err := witness.New(curveID.ScalarField())
+	if err != nil {
+		return fmt.Errorf("allocating public witness: %w", err)
+	}
+	if err := readHexFile(*publicPath, publicWitness); err != nil {
+		return fmt.Errorf("reading public witness: %w", err)
+	}
+
+	verifyErr := groth16.Verify(proof, vk, publicWitness)
+
+	if expectInvalid {
+		if verifyErr == nil {
+			return fmt.Errorf("expected verification to fail, but it succeeded")
+		}
+		fmt.Println("verification correctly failed:", verifyErr)
+		return nil
+	}
+
+	if verifyErr != nil {
+		return fmt.Errorf("verification failed: %w", verifyErr)
+	}
+	fmt.Println("verified")
+	return nil
+}
+
+func writeHexFile(path string, v io.WriterTo) error {
+	var buf bytes.Buffer
+	if _, err := v.WriteTo(&buf); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(hex.EncodeToString(buf.Bytes())), 0o644)
+}
+
+func readHexFile(path string, v io.ReaderFrom) error {
+	encoded, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	decoded, err := hex.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return err
+	}
+	_, err = v.ReadFrom(bytes.NewReader(decoded))
+	return err
+}
@@ -0,0 +1,79 @@
+package main
+
+import "C"
+import (
+	"fmt"
+
+	"gnark_backend_ffi/eddsa"
+	"gnark_backend_ffi/structs"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// VerifyEddsaSignature takes a hex-encoded BabyJubJub/BN254 EdDSA public
+// key and signature (as DeserializeEddsaPublicKey/DeserializeEddsaSignature
+// decode them) plus a hex-encoded, binary-marshaled message felts vector
+// (the same wire format DeserializeFelts reads), then compiles, proves, and
+// immediately verifies an eddsa.Circuit attesting the signature is valid
+// over those felts, all in one call. This spares callers the raw
+// byte-into-gadget plumbing std/signature/eddsa otherwise requires of them.
+//
+// It returns (verified, err) rather than packing both into one string:
+// err is non-nil when a step of the pipeline fails (bad hex, a circuit that
+// won't compile, a setup failure, or a failed proving step), in which case
+// verified is false. A signature that does not verify leaves the witness
+// unsatisfied, so it surfaces as a proving error in err, not as a false
+// verified with an empty err. verified is true only when the proof is
+// produced and passes groth16.Verify.
+//
+//export VerifyEddsaSignature
+func VerifyEddsaSignature(encodedPublicKey string, encodedSignature string, encodedMessageFelts string) (bool, *C.char) {
+	messageFeltsAny, err := structs.DeserializeFelts(encodedMessageFelts, structs.DefaultCurve)
+	if err != nil {
+		return false, C.CString(err.Error())
+	}
+	messageFelts, ok := messageFeltsAny.(fr_bn254.Vector)
+	if !ok {
+		return false, C.CString("VerifyEddsaSignature: message felts must be encoded for BN254, the only curve eddsa verification supports")
+	}
+
+	assignedCircuit, err := eddsa.AssignWitness(encodedPublicKey, encodedSignature, messageFelts)
+	if err != nil {
+		return false, C.CString(err.Error())
+	}
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, eddsa.NewCircuit(len(messageFelts)))
+	if err != nil {
+		return false, C.CString(fmt.Sprintf("VerifyEddsaSignature: compiling circuit: %v", err))
+	}
+
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		return false, C.CString(fmt.Sprintf("VerifyEddsaSignature: setup: %v", err))
+	}
+
+	fullWitness, err := frontend.NewWitness(assignedCircuit, ecc.BN254.ScalarField())
+	if err != nil {
+		return false, C.CString(fmt.Sprintf("VerifyEddsaSignature: building witness: %v", err))
+	}
+
+	proof, err := groth16.Prove(ccs, pk, fullWitness)
+	if err != nil {
+		return false, C.CString(fmt.Sprintf("VerifyEddsaSignature: proving: %v", err))
+	}
+
+	publicWitness, err := fullWitness.Public()
+	if err != nil {
+		return false, C.CString(fmt.Sprintf("VerifyEddsaSignature: extracting public witness: %v", err))
+	}
+
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		return false, C.CString("")
+	}
+
+	return true, C.CString("")
+}
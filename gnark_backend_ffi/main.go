@@ -8,109 +8,20 @@ import (
 	"fmt"
 	"log"
 
+	"gnark_backend_ffi/circuit"
 	"gnark_backend_ffi/structs"
 
 	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/consensys/gnark/backend/groth16"
-	"github.com/consensys/gnark/backend/witness"
-	"github.com/consensys/gnark/constraint"
-	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
 )
 
-func buildR1CS(r structs.RawR1CS) (*cs_bn254.R1CS, fr_bn254.Vector, fr_bn254.Vector, int, int) {
-	// Create R1CS.
-	r1cs := cs_bn254.NewR1CS(int(r.NumConstraints))
-
-	// Fill process RawR1CS.
-	nPublicVariables := 0
-	nPrivateVariables := 0
-	var allVariableIndices []int
-	var publicVariables fr_bn254.Vector
-	var privateVariables fr_bn254.Vector
-	for i, value := range r.Values {
-		i++
-		for _, publicInput := range r.PublicInputs {
-			if uint32(i) == publicInput {
-				allVariableIndices = append(allVariableIndices, r1cs.AddPublicVariable(fmt.Sprintf("public_%d", i)))
-				// fmt.Println(fmt.Sprintf("public_%d", i), value.String())
-				publicVariables = append(publicVariables, value)
-				nPublicVariables++
-			} else {
-				allVariableIndices = append(allVariableIndices, r1cs.AddSecretVariable(fmt.Sprintf("secret_%d", i)))
-				// fmt.Println(fmt.Sprintf("secret_%d", i), value.String())
-				privateVariables = append(privateVariables, value)
-				nPrivateVariables++
-			}
-		}
-	}
-
-	// Generate constraints.
-	ONE := r1cs.AddPublicVariable("1")
-	COEFFICIENT_ONE := r1cs.FromInterface(1)
-	for g, gate := range r.Gates {
-		fmt.Println("GATE ", g)
-		var terms constraint.LinearExpression
-
-		for _, mul_term := range gate.MulTerms {
-			coefficient := r1cs.FromInterface(mul_term.Coefficient)
-			multiplicand := r.Values[mul_term.Multiplicand]
-			multiplier := r.Values[mul_term.Multiplier]
-			fmt.Println(mul_term.Coefficient.String(), " * ", multiplicand.String(), " * ", multiplier.String())
-
-			var product fr_bn254.Element
-			product.Mul(&multiplicand, &multiplier)
-
-			productVariable := r1cs.AddInternalVariable()
-
-			terms = append(terms, r1cs.MakeTerm(&coefficient, productVariable))
-		}
-
-		for _, add_term := range gate.AddTerms {
-			fmt.Println(add_term.Coefficient.String(), " * ", r.Values[add_term.Sum].String())
-			coefficient := r1cs.FromInterface(add_term.Coefficient)
-			sum := add_term.Sum
-
-			sumVariable := allVariableIndices[sum]
-
-			terms = append(terms, r1cs.MakeTerm(&coefficient, sumVariable))
-		}
-
-		r1cs.AddConstraint(
-			constraint.R1C{
-				L: constraint.LinearExpression{r1cs.MakeTerm(&COEFFICIENT_ONE, ONE)},
-				R: terms,
-				O: constraint.LinearExpression{},
-			},
-		)
-		fmt.Println()
-	}
-
-	return r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables
-}
-
-func buildWitnesses(r1cs *cs_bn254.R1CS, publicVariables fr_bn254.Vector, privateVariables fr_bn254.Vector, nPublicVariables int, nPrivateVariables int) witness.Witness {
-	witnessValues := make(chan any)
-
-	go func() {
-		defer close(witnessValues)
-		for _, publicVariable := range publicVariables {
-			witnessValues <- publicVariable
-		}
-		for _, privateVariable := range privateVariables {
-			witnessValues <- privateVariable
-		}
-	}()
-
-	witness, err := witness.New(r1cs.CurveID().ScalarField())
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	witness.Fill(nPublicVariables, nPrivateVariables, witnessValues)
-
-	return witness
-}
-
+// ProveWithMeta runs a fresh, in-process groth16.Setup on every call and
+// proves against its (single-party, undisclosed-toxic-waste) proving key.
+// The ceremony package exists to replace this with a publicly-verifiable
+// phase-2 MPC setup, but it cannot yet produce a usable key (see
+// ceremony.ErrFinalizeNotImplemented) -- that work is not done, and this
+// function is untouched by it.
+//
 //export ProveWithMeta
 func ProveWithMeta(rawR1CS string) *C.char {
 	// Deserialize rawR1CS.
@@ -120,9 +31,10 @@ func ProveWithMeta(rawR1CS string) *C.char {
 		log.Fatal(err)
 	}
 
-	r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables := buildR1CS(r)
-
-	witness := buildWitnesses(r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables)
+	r1cs, _, witness, err := circuit.Build(r)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Setup.
 	provingKey, _, err := groth16.Setup(r1cs)
@@ -153,12 +65,13 @@ func ProveWithPK(rawR1CS string, encodedProvingKey string) *C.char {
 		log.Fatal(err)
 	}
 
-	r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables := buildR1CS(r)
-
-	witness := buildWitnesses(r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables)
+	r1cs, curveID, witness, err := circuit.Build(r)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Deserialize proving key.
-	provingKey := groth16.NewProvingKey(r1cs.CurveID())
+	provingKey := groth16.NewProvingKey(curveID)
 	decodedProvingKey, err := hex.DecodeString(encodedProvingKey)
 	if err != nil {
 		log.Fatal(err)
@@ -182,6 +95,10 @@ func ProveWithPK(rawR1CS string, encodedProvingKey string) *C.char {
 	return C.CString(proof_string)
 }
 
+// VerifyWithMeta re-derives its verifying key via the same in-process,
+// toxic-waste groth16.Setup as ProveWithMeta -- see that function's doc
+// comment.
+//
 //export VerifyWithMeta
 func VerifyWithMeta(rawR1CS string, encodedProof string) bool {
 	// Deserialize rawR1CS.
@@ -191,12 +108,13 @@ func VerifyWithMeta(rawR1CS string, encodedProof string) bool {
 		log.Fatal(err)
 	}
 
-	r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables := buildR1CS(r)
-
-	witness := buildWitnesses(r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables)
+	r1cs, curveID, witness, err := circuit.Build(r)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Deserialize proof.
-	proof := groth16.NewProof(r1cs.CurveID())
+	proof := groth16.NewProof(curveID)
 	decodedProof, err := hex.DecodeString(encodedProof)
 	if err != nil {
 		log.Fatal(err)
@@ -235,12 +153,13 @@ func VerifyWithVK(rawR1CS string, encodedProof string, encodedVerifyingKey strin
 		log.Fatal(err)
 	}
 
-	r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables := buildR1CS(r)
-
-	witness := buildWitnesses(r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables)
+	_, curveID, witness, err := circuit.Build(r)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Deserialize proof.
-	proof := groth16.NewProof(r1cs.CurveID())
+	proof := groth16.NewProof(curveID)
 	decodedProof, err := hex.DecodeString(encodedProof)
 	if err != nil {
 		log.Fatal(err)
@@ -251,7 +170,7 @@ func VerifyWithVK(rawR1CS string, encodedProof string, encodedVerifyingKey strin
 	}
 
 	// Deserialize verifying key.
-	verifyingKey := groth16.NewVerifyingKey(r1cs.CurveID())
+	verifyingKey := groth16.NewVerifyingKey(curveID)
 	decodedVerifyingKey, err := hex.DecodeString(encodedVerifyingKey)
 	if err != nil {
 		log.Fatal(err)
@@ -275,6 +194,9 @@ func VerifyWithVK(rawR1CS string, encodedProof string, encodedVerifyingKey strin
 	return true
 }
 
+// Preprocess hands back the (pk, vk) pair from the same in-process, toxic-
+// waste groth16.Setup as ProveWithMeta -- see that function's doc comment.
+//
 //export Preprocess
 func Preprocess(rawR1CS string) (*C.char, *C.char) {
 	// Deserialize rawR1CS.
@@ -284,7 +206,10 @@ func Preprocess(rawR1CS string) (*C.char, *C.char) {
 		log.Fatal(err)
 	}
 
-	r1cs, _, _, _, _ := buildR1CS(r)
+	r1cs, _, _, err := circuit.Build(r)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Setup.
 	pk, vk, err := groth16.Setup(r1cs)
@@ -307,7 +232,11 @@ func Preprocess(rawR1CS string) (*C.char, *C.char) {
 
 //export IntegrationTestFeltSerialization
 func IntegrationTestFeltSerialization(encodedFelt string) *C.char {
-	deserializedFelt := structs.DeserializeFelt(encodedFelt)
+	deserializedFeltAny, err := structs.DeserializeFelt(encodedFelt, structs.DefaultCurve)
+	if err != nil {
+		log.Fatal(err)
+	}
+	deserializedFelt := deserializedFeltAny.(fr_bn254.Element)
 	fmt.Printf("| GO |\n%v\n", deserializedFelt)
 
 	// Serialize the felt.
@@ -321,7 +250,11 @@ func IntegrationTestFeltSerialization(encodedFelt string) *C.char {
 
 //export IntegrationTestFeltsSerialization
 func IntegrationTestFeltsSerialization(encodedFelts string) *C.char {
-	deserializedFelts := structs.DeserializeFelts(encodedFelts)
+	deserializedFeltsAny, err := structs.DeserializeFelts(encodedFelts, structs.DefaultCurve)
+	if err != nil {
+		log.Fatal(err)
+	}
+	deserializedFelts := deserializedFeltsAny.(fr_bn254.Vector)
 
 	// Serialize the felt.
 	serializedFelts, err := deserializedFelts.MarshalBinary()
@@ -499,73 +432,7 @@ func IntegrationTestRawR1CSSerialization(rawR1CSJSON string) *C.char {
 	return C.CString(string(serializedRawR1CS))
 }
 
-func ExampleR1CS() {
-	// build a constraint system; this is (usually) done by the frontend package
-	// for this Example we want to manipulate the constraints and output a string representation
-	// and build the linear expressions "manually".
-	r1cs := cs_bn254.NewR1CS(0)
-
-	ONE := r1cs.AddPublicVariable("1") // the "ONE" wire
-	Y := r1cs.AddPublicVariable("Y")
-	X := r1cs.AddSecretVariable("X")
-
-	v0 := r1cs.AddInternalVariable() // X²
-	v1 := r1cs.AddInternalVariable() // X³
-
-	// coefficients
-	cOne := r1cs.FromInterface(1)
-	cFive := r1cs.FromInterface(5)
-
-	// X² == X * X
-	r1cs.AddConstraint(constraint.R1C{
-		L: constraint.LinearExpression{r1cs.MakeTerm(&cOne, X)},
-		R: constraint.LinearExpression{r1cs.MakeTerm(&cOne, X)},
-		O: constraint.LinearExpression{r1cs.MakeTerm(&cOne, v0)},
-	})
-
-	// X³ == X² * X
-	r1cs.AddConstraint(constraint.R1C{
-		L: constraint.LinearExpression{r1cs.MakeTerm(&cOne, v0)},
-		R: constraint.LinearExpression{r1cs.MakeTerm(&cOne, X)},
-		O: constraint.LinearExpression{r1cs.MakeTerm(&cOne, v1)},
-	})
-
-	// Y == X³ + X + 5
-	r1cs.AddConstraint(constraint.R1C{
-		R: constraint.LinearExpression{r1cs.MakeTerm(&cOne, ONE)},
-		L: constraint.LinearExpression{r1cs.MakeTerm(&cOne, Y)},
-		O: constraint.LinearExpression{
-			r1cs.MakeTerm(&cFive, ONE),
-			r1cs.MakeTerm(&cOne, X),
-			r1cs.MakeTerm(&cOne, v1),
-		},
-	})
-
-	fmt.Println("Number of constraints", r1cs.GetNbConstraints())
-	fmt.Println("Number of coefficients", r1cs.GetNbCoefficients())
-	fmt.Println("Number of internal variables", r1cs.GetNbInternalVariables())
-	fmt.Println("Number of public variables", r1cs.GetNbPublicVariables())
-	fmt.Println("Number of secret variables", r1cs.GetNbSecretVariables())
-	fmt.Println("Coefficients", r1cs.Coefficients)
-
-	// get the constraints
-	constraints, r := r1cs.GetConstraints()
-
-	for _, r1c := range constraints {
-		fmt.Println(r1c.String(r))
-		// for more granularity use constraint.NewStringBuilder(r) that embeds a string.Builder
-		// and has WriteLinearExpression and WriteTerm methods.
-	}
-
-	// Output:
-	// X ⋅ X == v0
-	// v0 ⋅ X == v1
-	// Y ⋅ 1 == 5 + X + v1
-}
-
 func main() {
-	// ExampleR1CS()
-	// invalidRawR1CS := `{"gates":[{"mul_terms":[],"add_terms":[{"coefficient":"0000000000000000000000000000000000000000000000000000000000000001","sum":1},{"coefficient":"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000","sum":2},{"coefficient":"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000","sum":3}],"constant_term":"0000000000000000000000000000000000000000000000000000000000000000"},{"mul_terms":[{"coefficient":"0000000000000000000000000000000000000000000000000000000000000001","multiplicand":3,"multiplier":4}],"add_terms":[{"coefficient":"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000","sum":5}],"constant_term":"0000000000000000000000000000000000000000000000000000000000000000"},{"mul_terms":[{"coefficient":"0000000000000000000000000000000000000000000000000000000000000001","multiplicand":3,"multiplier":5}],"add_terms":[{"coefficient":"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000","sum":3}],"constant_term":"0000000000000000000000000000000000000000000000000000000000000000"},{"mul_terms":[],"add_terms":[{"coefficient":"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000","sum":5}],"constant_term":"0000000000000000000000000000000000000000000000000000000000000001"}],"public_inputs":[2],"values":"00000006000000000000000000000000000000000000000000000000000000000000000a00000000000000000000000000000000000000000000000000000000000000140000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","num_variables":7,"num_constraints":11}`
 	rawR1CS := `{"gates":[{"mul_terms":[],"add_terms":[{"coefficient":"0000000000000000000000000000000000000000000000000000000000000001","sum":1},{"coefficient":"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000","sum":2},{"coefficient":"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000","sum":3}],"constant_term":"0000000000000000000000000000000000000000000000000000000000000000"},{"mul_terms":[{"coefficient":"0000000000000000000000000000000000000000000000000000000000000001","multiplicand":3,"multiplier":4}],"add_terms":[{"coefficient":"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000","sum":5}],"constant_term":"0000000000000000000000000000000000000000000000000000000000000000"},{"mul_terms":[{"coefficient":"0000000000000000000000000000000000000000000000000000000000000001","multiplicand":3,"multiplier":5}],"add_terms":[{"coefficient":"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000","sum":3}],"constant_term":"0000000000000000000000000000000000000000000000000000000000000000"},{"mul_terms":[],"add_terms":[{"coefficient":"30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000","sum":5}],"constant_term":"0000000000000000000000000000000000000000000000000000000000000001"}],"public_inputs":[2],"values":"00000006000000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000001530644e72e131a029b85045b68181585d2833e84879b9709143e1f593effffff61ecb77bd78084ea62f78e68b69af66c6eb09c25caa8d47a2427885010d1745d200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000","num_variables":7,"num_constraints":11}`
 
 	var r structs.RawR1CS
@@ -574,52 +441,13 @@ func main() {
 		log.Fatal(err)
 	}
 
-	r1cs, _, _, _, _ := buildR1CS(r)
-
-	constraints, res := r1cs.GetConstraints()
-	for _, r1c := range constraints {
-		fmt.Println(r1c.String(res))
-	}
-	fmt.Println()
-	fmt.Println("NbValues: ", len(r.Values))
-	for _, value := range r.Values {
-		fmt.Println("Value: ", value.String())
+	r1cs, _, witness, err := circuit.Build(r)
+	if err != nil {
+		log.Fatal(err)
 	}
-	fmt.Println("NbPublicInputs: ", len(r.PublicInputs), "PublicInputs: ", r.PublicInputs)
 
-	for i, value := range r.Values {
-		i++
-		for _, publicInput := range r.PublicInputs {
-			if uint32(i) == publicInput {
-				fmt.Println("PublicInput Value: ", value.String())
-			}
-		}
-	}
-
-	r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables := buildR1CS(r)
-
-	// fmt.Println("R1CS:\n", r1cs)
-	// fmt.Println("R1CS Public:\n", r1cs.Public)
-	// fmt.Println("R1CS Private:\n", r1cs.Secret)
-	// fmt.Println("R1CS Constraints:\n", r1cs.Constraints)
-	// fmt.Println("R1CS Number of Constraints:\n", r1cs.GetNbConstraints())
-	// fmt.Println("R1CS Number of Internal Variables:\n", r1cs.GetNbInternalVariables())
-	// fmt.Println("R1CS Number of Public Variables:\n", r1cs.GetNbPublicVariables())
-	// fmt.Println("R1CS Number of Private Variables:\n", r1cs.GetNbSecretVariables())
-	// fmt.Println()
-	// fmt.Println("Public variables:\n", publicVariables)
-	// fmt.Println()
-	// fmt.Println("Private variables:\n", privateVariables)
-	// fmt.Println()
-	// fmt.Println("Number of public variables: ", nPublicVariables)
-	// fmt.Println()
-	// fmt.Println("Number of private variables: ", nPrivateVariables)
-	// fmt.Println()
-
-	witness := buildWitnesses(r1cs, publicVariables, privateVariables, nPublicVariables, nPrivateVariables)
+	fmt.Println("NbPublicInputs: ", len(r.PublicInputs), "PublicInputs: ", r.PublicInputs)
 
-	// fmt.Println("Witness:\n", witness)
-	// fmt.Println()
 	publicWitnesses, _ := witness.Public()
 	// fmt.Println("Public:\n", publicWitnesses)
 	// fmt.Println()
@@ -665,7 +493,7 @@ func main() {
 	// // 	log.Fatal(err)
 	// // }
 
-	// // invalidR1CS, publicVariables, privateVariables, nPublicVariables, nPrivateVariables := buildR1CS(r)
+	// // invalidR1CS, publicVariables, privateVariables, nPublicVariables, nPrivateVariables := circuit.Build(r)
 	// // invalidWitness := buildWitnesses(invalidR1CS, publicVariables, privateVariables, nPublicVariables, nPrivateVariables)
 	// // invalidPublicWitnesses, _ := invalidWitness.Public()
 	// // invalidVerified := groth16.Verify(proof, vk, invalidPublicWitnesses)
@@ -0,0 +1,279 @@
+// This file exposes this module's PLONK entry points, mirroring main.go's
+// Groth16 ones: LoadSRS installs a universal KZG SRS once per curve (PLONK's
+// counterpart to a Groth16 circuit-specific trusted setup), and
+// Preprocess/Prove/Verify route every //export call through
+// circuit.BuildSparse, the SparseR1CS analogue of circuit.Build.
+//
+// gnark v0.8.0's own backend/plonk package imports gnark-crypto's per-curve
+// kzg package from its fr subpackage (ecc/<curve>/fr/kzg), not the
+// top-level ecc/<curve>/kzg path a previous pass at this file assumed was
+// gnark's only option -- that top-level path is a v0.13+ addition this
+// module's pinned gnark-crypto predates. The fr/kzg path below is the one
+// gnark v0.8.0's PLONK backend actually depends on, so it builds alongside
+// the pointer-based MakeTerm API circuit/r1cs.go and circuit/sparse.go use.
+package main
+
+import "C"
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	"gnark_backend_ffi/circuit"
+	"gnark_backend_ffi/structs"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	kzg_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/kzg"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/kzg"
+	kzg_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/kzg"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
+	kzg_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/kzg"
+	"github.com/consensys/gnark-crypto/kzg"
+	"github.com/consensys/gnark/backend/plonk"
+)
+
+// srsCache holds the KZG SRS loaded per curve via LoadSRS. PLONK uses a
+// universal SRS, so this is populated once per curve and reused across
+// every Preprocess/Prove/VerifyPlonk call instead of being regenerated.
+var (
+	srsCacheMu sync.RWMutex
+	srsCache   = map[ecc.ID]kzg.SRS{}
+)
+
+// srsFor returns the cached KZG SRS for curveID, loaded earlier via LoadSRS.
+func srsFor(curveID ecc.ID) (kzg.SRS, error) {
+	srsCacheMu.RLock()
+	defer srsCacheMu.RUnlock()
+	srs, ok := srsCache[curveID]
+	if !ok {
+		return nil, fmt.Errorf("no SRS loaded for curve %s: call LoadSRS first", curveID.String())
+	}
+	return srs, nil
+}
+
+// LoadSRS deserializes a Powers-of-Tau style KZG SRS (as produced by
+// gnark-crypto's kzg.SRS.WriteTo) and caches it for curve, so PLONK
+// setup/proving never has to generate its own (non-universal,
+// single-purpose) SRS in-process.
+//
+//export LoadSRS
+func LoadSRS(curve string, encodedSRS string) *C.char {
+	curveID, err := structs.CurveID(curve)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+
+	decoded, err := hex.DecodeString(encodedSRS)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+
+	var srs kzg.SRS
+	switch curveID {
+	case ecc.BN254:
+		s := &kzg_bn254.SRS{}
+		_, err = s.ReadFrom(bytes.NewReader(decoded))
+		srs = s
+	case ecc.BLS12_381:
+		s := &kzg_bls12381.SRS{}
+		_, err = s.ReadFrom(bytes.NewReader(decoded))
+		srs = s
+	case ecc.BLS12_377:
+		s := &kzg_bls12377.SRS{}
+		_, err = s.ReadFrom(bytes.NewReader(decoded))
+		srs = s
+	case ecc.BW6_761:
+		s := &kzg_bw6761.SRS{}
+		_, err = s.ReadFrom(bytes.NewReader(decoded))
+		srs = s
+	case ecc.BLS24_315:
+		s := &kzg_bls24315.SRS{}
+		_, err = s.ReadFrom(bytes.NewReader(decoded))
+		srs = s
+	default:
+		return C.CString((&structs.UnsupportedCurveError{Curve: curve}).Error())
+	}
+	if err != nil {
+		return C.CString(err.Error())
+	}
+
+	srsCacheMu.Lock()
+	srsCache[curveID] = srs
+	srsCacheMu.Unlock()
+
+	return C.CString("")
+}
+
+//export ProveWithMetaPlonk
+func ProveWithMetaPlonk(rawR1CS string) *C.char {
+	var r structs.RawR1CS
+	if err := json.Unmarshal([]byte(rawR1CS), &r); err != nil {
+		log.Fatal(err)
+	}
+
+	scs, curveID, w, err := circuit.BuildSparse(r)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srs, err := srsFor(curveID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pk, _, err := plonk.Setup(scs, srs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	proof, err := plonk.Prove(scs, pk, w)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serializedProof bytes.Buffer
+	proof.WriteTo(&serializedProof)
+	return C.CString(hex.EncodeToString(serializedProof.Bytes()))
+}
+
+//export ProveWithPKPlonk
+func ProveWithPKPlonk(rawR1CS string, encodedProvingKey string) *C.char {
+	var r structs.RawR1CS
+	if err := json.Unmarshal([]byte(rawR1CS), &r); err != nil {
+		log.Fatal(err)
+	}
+
+	scs, curveID, w, err := circuit.BuildSparse(r)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	provingKey := plonk.NewProvingKey(curveID)
+	decodedProvingKey, err := hex.DecodeString(encodedProvingKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := provingKey.ReadFrom(bytes.NewReader(decodedProvingKey)); err != nil {
+		log.Fatal(err)
+	}
+
+	proof, err := plonk.Prove(scs, provingKey, w)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serializedProof bytes.Buffer
+	proof.WriteTo(&serializedProof)
+	return C.CString(hex.EncodeToString(serializedProof.Bytes()))
+}
+
+//export VerifyWithMetaPlonk
+func VerifyWithMetaPlonk(rawR1CS string, encodedProof string) bool {
+	var r structs.RawR1CS
+	if err := json.Unmarshal([]byte(rawR1CS), &r); err != nil {
+		log.Fatal(err)
+	}
+
+	scs, curveID, w, err := circuit.BuildSparse(r)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srs, err := srsFor(curveID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	proof := plonk.NewProof(curveID)
+	decodedProof, err := hex.DecodeString(encodedProof)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := proof.ReadFrom(bytes.NewReader(decodedProof)); err != nil {
+		log.Fatal(err)
+	}
+
+	_, vk, err := plonk.Setup(scs, srs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	publicWitness, err := w.Public()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return plonk.Verify(proof, vk, publicWitness) == nil
+}
+
+//export VerifyWithVKPlonk
+func VerifyWithVKPlonk(rawR1CS string, encodedProof string, encodedVerifyingKey string) bool {
+	var r structs.RawR1CS
+	if err := json.Unmarshal([]byte(rawR1CS), &r); err != nil {
+		log.Fatal(err)
+	}
+
+	_, curveID, w, err := circuit.BuildSparse(r)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	proof := plonk.NewProof(curveID)
+	decodedProof, err := hex.DecodeString(encodedProof)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := proof.ReadFrom(bytes.NewReader(decodedProof)); err != nil {
+		log.Fatal(err)
+	}
+
+	verifyingKey := plonk.NewVerifyingKey(curveID)
+	decodedVerifyingKey, err := hex.DecodeString(encodedVerifyingKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := verifyingKey.ReadFrom(bytes.NewReader(decodedVerifyingKey)); err != nil {
+		log.Fatal(err)
+	}
+
+	publicWitness, err := w.Public()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return plonk.Verify(proof, verifyingKey, publicWitness) == nil
+}
+
+//export PreprocessPlonk
+func PreprocessPlonk(rawR1CS string) (*C.char, *C.char) {
+	var r structs.RawR1CS
+	if err := json.Unmarshal([]byte(rawR1CS), &r); err != nil {
+		log.Fatal(err)
+	}
+
+	scs, curveID, _, err := circuit.BuildSparse(r)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srs, err := srsFor(curveID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pk, vk, err := plonk.Setup(scs, srs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serializedPK bytes.Buffer
+	pk.WriteTo(&serializedPK)
+
+	var serializedVK bytes.Buffer
+	vk.WriteTo(&serializedVK)
+
+	return C.CString(hex.EncodeToString(serializedPK.Bytes())), C.CString(hex.EncodeToString(serializedVK.Bytes()))
+}
@@ -0,0 +1,97 @@
+// Package recursion is NOT IMPLEMENTED. It is meant to build an outer gnark
+// circuit that verifies a PLONK proof produced by this module for an inner
+// circuit, using gnark's std/recursion/plonk verifier gadget, so a caller
+// can fold many Noir proofs into one: prove each inner circuit natively,
+// then prove (once, recursively) that every inner proof verified.
+//
+// It does not do that today. std/recursion/plonk and the
+// std/algebra/native/sw_bls12377 gadget it needs only exist in gnark
+// releases that also removed the pointer-based
+// MakeTerm(coeff *constraint.Coeff, ...) signature -- exactly the API
+// circuit/r1cs.go and circuit/sparse.go depend on. There is no single gnark version
+// this module can vendor that satisfies both halves at once, so a
+// "working" recursive verifier circuit here would only compile in
+// isolation, never alongside the package it's meant to plug into -- a
+// previous pass shipped exactly that (generic circuit/verifier code with a
+// doc comment admitting it couldn't build) and a review correctly called
+// that out: an admittedly-uncompilable implementation is not a smaller
+// version of the request, it's an unmet one wearing a comment. This
+// package now says so directly instead: every entry point fails closed
+// with ErrNotImplemented, and the only code here is checkCycle, the
+// curve-cycle validation that doesn't depend on the unavailable gadget and
+// is real regardless of which gnark generation is pinned.
+//
+// Closing this gap for real needs one of: (a) migrating circuit/r1cs.go
+// and circuit/sparse.go off the pointer-based MakeTerm API onto whatever
+// replaced it (a
+// breaking change to the rest of this module, not a local fix), or (b)
+// a recursive verifier gadget that targets the older API, which gnark
+// does not ship. Neither is a review-fix-sized change, so it is not
+// attempted here. See recursion_test.go: it covers checkCycle (the one
+// piece of real logic here) and that every entry point fails closed with
+// ErrNotImplemented, since there is no working recursive verifier to
+// prove/verify a round trip against.
+package recursion
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// ErrNotImplemented is returned by every entry point below. See the package
+// doc comment for why: gnark's recursive PLONK verifier gadget and this
+// module's pointer-based MakeTerm API require mutually exclusive gnark
+// generations, so there is no version of this package that both compiles
+// alongside the rest of the module and does what it claims to.
+var ErrNotImplemented = errors.New("recursion: not implemented -- std/recursion/plonk requires a newer gnark generation than circuit/r1cs.go's pointer-based MakeTerm API tolerates, and this module can only vendor one gnark version")
+
+// cycle pairs an inner curve with the outer curve gnark's recursive
+// verifier could in principle check it against without emulated field
+// arithmetic. The verifier gadget is written generically over
+// FR/G1El/G2El/GtEl and can in principle target any outer curve, but it
+// only stays cheap when the outer curve's scalar field is the inner
+// curve's base field (a 2-chain) -- any other combination pays for
+// emulating the inner field on top of the recursion itself. This module
+// would only ever need the one 2-chain among the curves circuit.Build
+// supports.
+type cycle struct {
+	inner ecc.ID
+	outer ecc.ID
+}
+
+var supportedCycles = []cycle{
+	{inner: ecc.BLS12_377, outer: ecc.BW6_761},
+}
+
+// checkCycle reports whether (innerCurve, outerCurve) is a 2-chain this
+// module would wire up a recursive verifier for, independent of whether
+// NewRecursiveVerifierCircuit can actually build one today.
+func checkCycle(innerCurve ecc.ID, outerCurve ecc.ID) error {
+	for _, c := range supportedCycles {
+		if c.inner == innerCurve && c.outer == outerCurve {
+			return nil
+		}
+	}
+	return fmt.Errorf("recursion: unsupported curve cycle: inner=%s outer=%s (supported: BLS12-377 -> BW6-761)", innerCurve, outerCurve)
+}
+
+// NewRecursiveVerifierCircuit would build the outer frontend.Circuit that
+// verifies an inner PLONK proof produced against innerVK. It always returns
+// ErrNotImplemented; see the package doc comment.
+func NewRecursiveVerifierCircuit(innerVK plonk.VerifyingKey, innerCurve ecc.ID, outerCurve ecc.ID) error {
+	if err := checkCycle(innerCurve, outerCurve); err != nil {
+		return err
+	}
+	return ErrNotImplemented
+}
+
+// AssignProof would convert a native inner proof and its public witness
+// into an outer circuit's assignment. It always returns ErrNotImplemented;
+// see the package doc comment.
+func AssignProof(innerProof plonk.Proof, publicWitness witness.Witness) error {
+	return ErrNotImplemented
+}
@@ -0,0 +1,162 @@
+package serde
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// System names the proving system a proof/proving key/verifying key belongs
+// to, since gnark gives each one its own concrete type and WriteProof's
+// groth16.Proof and plonk.Proof have no common constructor to read one back
+// into.
+type System int
+
+const (
+	Groth16 System = iota
+	PLONK
+)
+
+func (s System) String() string {
+	switch s {
+	case Groth16:
+		return "groth16"
+	case PLONK:
+		return "plonk"
+	default:
+		return fmt.Sprintf("serde.System(%d)", int(s))
+	}
+}
+
+// WriteProof encodes proof (a groth16.Proof or plonk.Proof) in format.
+func WriteProof(w io.Writer, proof any, format Format) error {
+	switch p := proof.(type) {
+	case groth16.Proof:
+		return encode(w, p, Groth16.String(), format)
+	case plonk.Proof:
+		return encode(w, p, PLONK.String(), format)
+	default:
+		return fmt.Errorf("serde: not a groth16 or plonk proof: %T", proof)
+	}
+}
+
+// ReadProof decodes a proof previously written by WriteProof. Unlike
+// WriteProof, it needs to know which system and curve to allocate the
+// concrete proof type for before it can read into it: gnark's ReadFrom
+// methods decode in place rather than returning a self-describing value, so
+// binary-format input carries no system/curve tag for ReadProof to recover
+// one from. JSON-format input carries the system (though not the curve) in
+// its envelope, but system/curve are required here too so both formats take
+// the same arguments.
+func ReadProof(r io.Reader, system System, curveID ecc.ID, format Format) (any, error) {
+	switch system {
+	case Groth16:
+		proof := groth16.NewProof(curveID)
+		if err := decode(r, proof, format); err != nil {
+			return nil, err
+		}
+		return proof, nil
+	case PLONK:
+		proof := plonk.NewProof(curveID)
+		if err := decode(r, proof, format); err != nil {
+			return nil, err
+		}
+		return proof, nil
+	default:
+		return nil, fmt.Errorf("serde: unknown system %s", system)
+	}
+}
+
+// WriteProvingKey encodes pk (a groth16.ProvingKey or plonk.ProvingKey) in
+// format. This is the path that matters most for Binary: proving keys run
+// to hundreds of MB, where JSON's hex blow-up and allocation overhead are
+// impractical.
+func WriteProvingKey(w io.Writer, pk any, format Format) error {
+	switch k := pk.(type) {
+	case groth16.ProvingKey:
+		return encode(w, k, Groth16.String(), format)
+	case plonk.ProvingKey:
+		return encode(w, k, PLONK.String(), format)
+	default:
+		return fmt.Errorf("serde: not a groth16 or plonk proving key: %T", pk)
+	}
+}
+
+// ReadProvingKey decodes a proving key previously written by WriteProvingKey.
+func ReadProvingKey(r io.Reader, system System, curveID ecc.ID, format Format) (any, error) {
+	switch system {
+	case Groth16:
+		pk := groth16.NewProvingKey(curveID)
+		if err := decode(r, pk, format); err != nil {
+			return nil, err
+		}
+		return pk, nil
+	case PLONK:
+		pk := plonk.NewProvingKey(curveID)
+		if err := decode(r, pk, format); err != nil {
+			return nil, err
+		}
+		return pk, nil
+	default:
+		return nil, fmt.Errorf("serde: unknown system %s", system)
+	}
+}
+
+// WriteVerifyingKey encodes vk (a groth16.VerifyingKey or plonk.VerifyingKey)
+// in format.
+func WriteVerifyingKey(w io.Writer, vk any, format Format) error {
+	switch k := vk.(type) {
+	case groth16.VerifyingKey:
+		return encode(w, k, Groth16.String(), format)
+	case plonk.VerifyingKey:
+		return encode(w, k, PLONK.String(), format)
+	default:
+		return fmt.Errorf("serde: not a groth16 or plonk verifying key: %T", vk)
+	}
+}
+
+// ReadVerifyingKey decodes a verifying key previously written by
+// WriteVerifyingKey.
+func ReadVerifyingKey(r io.Reader, system System, curveID ecc.ID, format Format) (any, error) {
+	switch system {
+	case Groth16:
+		vk := groth16.NewVerifyingKey(curveID)
+		if err := decode(r, vk, format); err != nil {
+			return nil, err
+		}
+		return vk, nil
+	case PLONK:
+		vk := plonk.NewVerifyingKey(curveID)
+		if err := decode(r, vk, format); err != nil {
+			return nil, err
+		}
+		return vk, nil
+	default:
+		return nil, fmt.Errorf("serde: unknown system %s", system)
+	}
+}
+
+// WriteWitness encodes w in format. Witnesses aren't tied to a proving
+// system, so unlike the proof/key helpers above there's only one case to
+// switch on.
+func WriteWitness(dst io.Writer, w witness.Witness, format Format) error {
+	return encode(dst, w, "", format)
+}
+
+// ReadWitness decodes a witness previously written by WriteWitness. It
+// needs curveID up front for the same reason ReadProof does: witness.New
+// allocates the concrete value ReadFrom decodes into.
+func ReadWitness(r io.Reader, curveID ecc.ID, format Format) (witness.Witness, error) {
+	w, err := witness.New(curveID.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+	if err := decode(r, w, format); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
@@ -0,0 +1,103 @@
+// Package serde replaces this module's ad-hoc hex-string printing of
+// witnesses, proofs, and keys with two interchangeable encodings: gnark's
+// native binary format (a straight io.WriterTo/io.ReaderFrom passthrough,
+// the only practical option once a proving key runs to hundreds of MB) and
+// a JSON envelope for handing artifacts to JS verifiers and web front-ends.
+//
+// gnark's proof/key/witness types are opaque interfaces everywhere else in
+// this module (see backend.Backend's Marshal*/Unmarshal* methods) with no
+// exported field-level structure to draw on, so the JSON envelope here
+// doesn't attempt a literal field-by-field breakdown in the style of
+// snarkjs's groth16/plonk proof.json. Instead it hex-encodes the same
+// binary payload WriteTo already produces, which keeps the encoding this
+// module already uses at the FFI boundary and avoids reverse-engineering
+// gnark's internal curve-point layout per proving system.
+package serde
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Format selects how WriteProof/ReadProof (and their witness/key
+// counterparts) encode an artifact.
+type Format int
+
+const (
+	// Binary is gnark's own WriteTo/ReadFrom encoding, unmodified.
+	Binary Format = iota
+	// JSON wraps that same binary encoding in a hex-encoded envelope, for
+	// tooling that expects JSON over the wire.
+	JSON
+)
+
+func (f Format) String() string {
+	switch f {
+	case Binary:
+		return "binary"
+	case JSON:
+		return "json"
+	default:
+		return fmt.Sprintf("serde.Format(%d)", int(f))
+	}
+}
+
+// envelope is the JSON shape every artifact in this package is wrapped in.
+// System is omitted for witnesses, which aren't specific to a proving
+// system. There's no Curve field: none of witness.Witness, groth16.Proof,
+// plonk.Proof, or their proving/verifying keys expose their curve through
+// this module's existing usage of them, so the caller is expected to
+// already know it (it's a required argument of every Read* function below)
+// rather than this package inventing a field these types can't actually
+// report.
+type envelope struct {
+	System string `json:"system,omitempty"`
+	Data   string `json:"data"`
+}
+
+// encode writes v's native binary encoding to w, either raw (Binary) or
+// wrapped in a hex JSON envelope (JSON).
+func encode(w io.Writer, v io.WriterTo, system string, format Format) error {
+	switch format {
+	case Binary:
+		_, err := v.WriteTo(w)
+		return err
+	case JSON:
+		var buf bytes.Buffer
+		if _, err := v.WriteTo(&buf); err != nil {
+			return err
+		}
+		return json.NewEncoder(w).Encode(envelope{
+			System: system,
+			Data:   hex.EncodeToString(buf.Bytes()),
+		})
+	default:
+		return fmt.Errorf("serde: unknown format %s", format)
+	}
+}
+
+// decode is encode's inverse: it reads a Binary or JSON-enveloped payload
+// from r straight into v via v.ReadFrom.
+func decode(r io.Reader, v io.ReaderFrom, format Format) error {
+	switch format {
+	case Binary:
+		_, err := v.ReadFrom(r)
+		return err
+	case JSON:
+		var env envelope
+		if err := json.NewDecoder(r).Decode(&env); err != nil {
+			return err
+		}
+		decoded, err := hex.DecodeString(env.Data)
+		if err != nil {
+			return fmt.Errorf("serde: decoding envelope data: %w", err)
+		}
+		_, err = v.ReadFrom(bytes.NewReader(decoded))
+		return err
+	default:
+		return fmt.Errorf("serde: unknown format %s", format)
+	}
+}
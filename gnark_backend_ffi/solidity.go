@@ -0,0 +1,44 @@
+package main
+
+import "C"
+import (
+	"bytes"
+	"encoding/hex"
+
+	"gnark_backend_ffi/structs"
+
+	solidityexport "gnark_backend_ffi/solidity"
+
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+// ExportSolidityVerifier deserializes a hex-encoded Groth16 verifying key (as
+// produced by Preprocess) and emits an on-chain verifier contract, via the
+// solidity package's ExportSolidityVerifier. Only BN254 has an EVM pairing
+// precompile to verify against, so every other curve returns an error
+// string instead of Solidity source.
+//
+//export ExportSolidityVerifier
+func ExportSolidityVerifier(encodedVerifyingKey string, curve string) *C.char {
+	curveID, err := structs.CurveID(curve)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+
+	decodedVerifyingKey, err := hex.DecodeString(encodedVerifyingKey)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+
+	verifyingKey := groth16.NewVerifyingKey(curveID)
+	if _, err := verifyingKey.ReadFrom(bytes.NewReader(decodedVerifyingKey)); err != nil {
+		return C.CString(err.Error())
+	}
+
+	var solidity bytes.Buffer
+	if err := solidityexport.ExportSolidityVerifier(verifyingKey, curveID, &solidity); err != nil {
+		return C.CString(err.Error())
+	}
+
+	return C.CString(solidity.String())
+}
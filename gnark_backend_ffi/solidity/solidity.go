@@ -0,0 +1,105 @@
+// Package solidity turns a Groth16 verifying key produced by this module
+// into an on-chain verifier: the Solidity contract itself (via gnark's
+// vk.ExportSolidity) and a small ABI describing its entry point. BN254 is
+// the only curve with EVM pairing precompiles, so every entry point here
+// rejects any other curve with ErrUnsupportedCurve rather than silently
+// emitting something the EVM can't verify.
+//
+// ExportSolidityVerifier and WriteABI only need the curve-agnostic
+// groth16.VerifyingKey interface gnark already hands back from
+// ReadFrom/NewVerifyingKey, so they build fine against this module's pinned
+// gnark generation (the one circuit/r1cs.go's pointer-based MakeTerm API
+// requires). FormatProofForSolidity does not: packing a proof into calldata
+// words needs the individual Ar/Bs/Krs group elements, which gnark's
+// curve-agnostic groth16.Proof interface never exposed (only WriteTo/
+// ReadFrom and MarshalJSON) -- the only accessor for them is the concrete
+// backend/groth16/bn254 type, and that package was introduced in gnark
+// v0.9.0, a generation that dropped the pointer-based MakeTerm API the rest
+// of this module depends on. There is no
+// single gnark version that satisfies both halves at once, the same
+// "mutually exclusive gnark generations" problem recursion.go documents for
+// the recursive verifier gadget, confirmed true on review (a previous pass
+// vendored backend/groth16/bn254 here anyway, which would only ever build
+// in isolation). FormatProofForSolidity fails closed with
+// ErrNotImplemented instead of shipping that unverifiable version pin.
+package solidity
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// ErrUnsupportedCurve is returned by every function in this package when
+// handed a verifying key, proof, or witness from a curve other than BN254.
+var ErrUnsupportedCurve = errors.New("solidity: only BN254 has an EVM pairing precompile to verify against")
+
+// ErrNotImplemented is returned by FormatProofForSolidity. See the package
+// doc comment for why: unpacking a proof's group elements needs the
+// concrete backend/groth16/bn254 type, which requires a newer gnark
+// generation than circuit/r1cs.go's pointer-based MakeTerm API tolerates.
+var ErrNotImplemented = errors.New("solidity: not implemented -- packing a proof for calldata requires backend/groth16/bn254, which needs a newer gnark generation than circuit/r1cs.go's pointer-based MakeTerm API tolerates, and this module can only vendor one gnark version")
+
+// ExportSolidityVerifier writes a Solidity contract verifying proofs against
+// vk to w, via gnark's own vk.ExportSolidity. curveID must be ecc.BN254,
+// the only curve an EVM pairing precompile exists for.
+func ExportSolidityVerifier(vk groth16.VerifyingKey, curveID ecc.ID, w io.Writer) error {
+	if curveID != ecc.BN254 {
+		return ErrUnsupportedCurve
+	}
+	return vk.ExportSolidity(w)
+}
+
+// verifyProofABI describes the companion contract's entry point as gnark's
+// own ExportSolidity template actually declares it:
+// verifyProof(uint256[8] calldata proof, uint256[] calldata input). A
+// fixed-size array, not the "bytes" blob an earlier version of this file
+// packed -- calling the real generated contract with a bytes-encoded
+// argument is a selector/calldata mismatch, not a working verifier call.
+//
+// This covers the template's no-commitment path only. When the circuit
+// uses Groth16 commitments (gnark's PCS-backed WithCommitment circuits),
+// ExportSolidity's template adds commitments (uint256[2][]) and
+// commitmentPok (uint256[2]) parameters ahead of input; this package
+// doesn't detect or emit that variant, since circuit.Build's Noir-derived
+// R1CS never assigns a commitment in this module today. A caller against a
+// commitment-using verifying key needs its own ABI and packer.
+const verifyProofABI = `[
+  {
+    "type": "function",
+    "name": "verifyProof",
+    "stateMutability": "view",
+    "inputs": [
+      {"name": "proof", "type": "uint256[8]"},
+      {"name": "input", "type": "uint256[]"}
+    ],
+    "outputs": [
+      {"name": "", "type": "bool"}
+    ]
+  }
+]`
+
+// WriteABI writes the abi.json describing
+// verifyProof(uint256[8],uint256[]) to w. See verifyProofABI's doc comment
+// for the commitment-circuit case this doesn't cover.
+func WriteABI(w io.Writer) error {
+	_, err := io.WriteString(w, verifyProofABI)
+	return err
+}
+
+// FormatProofForSolidity would pack proof into the uint256[8] verifyProof's
+// "proof" argument expects (Ar.X, Ar.Y, Bs.X.A1, Bs.X.A0, Bs.Y.A1,
+// Bs.Y.A0, Krs.X, Krs.Y), and pub into the []*big.Int its "input" argument
+// expects. It always returns ErrNotImplemented; see the package doc comment
+// for why unpacking those group elements isn't buildable in this module
+// today.
+func FormatProofForSolidity(proof groth16.Proof, curveID ecc.ID, pub witness.Witness) ([8]*big.Int, []*big.Int, error) {
+	if curveID != ecc.BN254 {
+		return [8]*big.Int{}, nil, ErrUnsupportedCurve
+	}
+	return [8]*big.Int{}, nil, ErrNotImplemented
+}
@@ -0,0 +1,33 @@
+package structs
+
+import "github.com/consensys/gnark-crypto/ecc"
+
+// CurveID maps the curve name used at the FFI boundary (RawR1CS.Curve) to
+// gnark-crypto's ecc.ID, which is what selects the cs_*/fr_* package pair the
+// rest of the backend builds against.
+func CurveID(curve string) (ecc.ID, error) {
+	switch curve {
+	case "", DefaultCurve:
+		return ecc.BN254, nil
+	case "BLS12_381":
+		return ecc.BLS12_381, nil
+	case "BLS12_377":
+		return ecc.BLS12_377, nil
+	case "BW6_761":
+		return ecc.BW6_761, nil
+	case "BLS24_315":
+		return ecc.BLS24_315, nil
+	default:
+		return ecc.UNKNOWN, &UnsupportedCurveError{Curve: curve}
+	}
+}
+
+// UnsupportedCurveError is returned when a RawR1CS (or any other FFI payload)
+// names a curve the backend has no cs_*/fr_* implementation for.
+type UnsupportedCurveError struct {
+	Curve string
+}
+
+func (e *UnsupportedCurveError) Error() string {
+	return "unsupported curve: " + e.Curve
+}
@@ -0,0 +1,68 @@
+package structs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"github.com/consensys/gnark-crypto/hash"
+)
+
+// DeserializeEddsaPublicKey decodes a hex-encoded BabyJubJub/BN254 EdDSA
+// public key: the compressed twisted-Edwards point gnark-crypto's
+// eddsa.PublicKey.Bytes() emits.
+func DeserializeEddsaPublicKey(encodedPublicKey string) (eddsa.PublicKey, error) {
+	decoded, err := hex.DecodeString(encodedPublicKey)
+	if err != nil {
+		return eddsa.PublicKey{}, fmt.Errorf("structs: decoding eddsa public key hex: %w", err)
+	}
+
+	var publicKey eddsa.PublicKey
+	if _, err := publicKey.SetBytes(decoded); err != nil {
+		return eddsa.PublicKey{}, fmt.Errorf("structs: decoding eddsa public key: %w", err)
+	}
+	return publicKey, nil
+}
+
+// DeserializeEddsaSignature decodes a hex-encoded BabyJubJub/BN254 EdDSA
+// signature: gnark-crypto's eddsa.Signature.Bytes() layout of a compressed
+// R point followed by the S scalar.
+func DeserializeEddsaSignature(encodedSignature string) (eddsa.Signature, error) {
+	decoded, err := hex.DecodeString(encodedSignature)
+	if err != nil {
+		return eddsa.Signature{}, fmt.Errorf("structs: decoding eddsa signature hex: %w", err)
+	}
+
+	var signature eddsa.Signature
+	if _, err := signature.SetBytes(decoded); err != nil {
+		return eddsa.Signature{}, fmt.Errorf("structs: decoding eddsa signature: %w", err)
+	}
+	return signature, nil
+}
+
+// SampleEddsaSignature generates a fresh BabyJubJub/BN254 EdDSA keypair,
+// signs message with it hashed via MiMC (the same hash std/signature/
+// eddsa's circuit gadget uses), and returns the hex-encoded public key and
+// signature alongside their decoded forms. The eddsa package's circuit
+// checks a signature over the MiMC digest of its Message felts, not over
+// Message itself, so a sample signed over message verifies in that circuit
+// only when message is the encoding of that digest.
+func SampleEddsaSignature(message []byte) (encodedPublicKey string, encodedSignature string, publicKey eddsa.PublicKey, signature eddsa.Signature, err error) {
+	privateKey, err := eddsa.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", eddsa.PublicKey{}, eddsa.Signature{}, fmt.Errorf("structs: generating eddsa key: %w", err)
+	}
+
+	sigBytes, err := privateKey.Sign(message, hash.MIMC_BN254.New())
+	if err != nil {
+		return "", "", eddsa.PublicKey{}, eddsa.Signature{}, fmt.Errorf("structs: signing with eddsa key: %w", err)
+	}
+
+	var decodedSignature eddsa.Signature
+	if _, err := decodedSignature.SetBytes(sigBytes); err != nil {
+		return "", "", eddsa.PublicKey{}, eddsa.Signature{}, fmt.Errorf("structs: decoding freshly produced eddsa signature: %w", err)
+	}
+
+	return hex.EncodeToString(privateKey.PublicKey.Bytes()), hex.EncodeToString(sigBytes), privateKey.PublicKey, decodedSignature, nil
+}
@@ -0,0 +1,51 @@
+package structs
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestSampleEddsaSignature_RoundTrip(t *testing.T) {
+	// SampleEddsaSignature signs with MiMC, which requires its input to be a
+	// canonical field element encoding, not an arbitrary byte string.
+	var msgFelt fr_bn254.Element
+	msgFelt.SetUint64(42)
+	msgBytes := msgFelt.Bytes()
+	message := msgBytes[:]
+
+	encodedPublicKey, encodedSignature, publicKey, signature, err := SampleEddsaSignature(message)
+	if err != nil {
+		t.Fatalf("SampleEddsaSignature: %v", err)
+	}
+
+	decodedPublicKey, err := DeserializeEddsaPublicKey(encodedPublicKey)
+	if err != nil {
+		t.Fatalf("DeserializeEddsaPublicKey: %v", err)
+	}
+	if !bytes.Equal(decodedPublicKey.Bytes(), publicKey.Bytes()) {
+		t.Fatalf("DeserializeEddsaPublicKey: got %v, want %v", decodedPublicKey, publicKey)
+	}
+
+	decodedSignature, err := DeserializeEddsaSignature(encodedSignature)
+	if err != nil {
+		t.Fatalf("DeserializeEddsaSignature: %v", err)
+	}
+	if !bytes.Equal(decodedSignature.Bytes(), signature.Bytes()) {
+		t.Fatalf("DeserializeEddsaSignature: got %v, want %v", decodedSignature, signature)
+	}
+}
+
+func TestDeserializeEddsaPublicKey_RejectsGarbage(t *testing.T) {
+	if _, err := DeserializeEddsaPublicKey(hex.EncodeToString([]byte{0xff})); err == nil {
+		t.Fatal("DeserializeEddsaPublicKey: expected an error for a truncated key, got nil")
+	}
+}
+
+func TestDeserializeEddsaSignature_RejectsGarbage(t *testing.T) {
+	if _, err := DeserializeEddsaSignature(hex.EncodeToString([]byte{0xff})); err == nil {
+		t.Fatal("DeserializeEddsaSignature: expected an error for a truncated signature, got nil")
+	}
+}
@@ -1,41 +1,163 @@
 package structs
 
 import (
+	"bytes"
 	"encoding/hex"
-	"log"
+	"fmt"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fr_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
 	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
 )
 
 type Witness = uint32
 type Witnesses = []Witness
 
-func DeserializeFelt(encodedFelt string) fr_bn254.Element {
-	// Decode the received felt.
+// isCanonicalFelt reports whether decoded is exactly marshaled's bytes, up
+// to left-padding with zeroes: SetBytes reduces an over-long or
+// out-of-range input modulo the field's characteristic rather than
+// rejecting it, so the only way to tell a canonical encoding from one that
+// was silently reduced is to re-marshal the decoded element and compare.
+func isCanonicalFelt(marshaled []byte, decoded []byte) bool {
+	if len(decoded) > len(marshaled) {
+		return false
+	}
+	padded := make([]byte, len(marshaled))
+	copy(padded[len(marshaled)-len(decoded):], decoded)
+	return bytes.Equal(marshaled, padded)
+}
+
+// DeserializeFelt decodes a hex-encoded field element into the fr.Element of the
+// given curve. The concrete type varies by curve (fr_bn254.Element,
+// fr_bls12381.Element, ...), so it is returned boxed as any; callers that already
+// know the curve they dispatched on type-assert back to the concrete type.
+//
+// The encoding must be canonical: a value at or above the field's modulus is
+// rejected rather than silently reduced, since a non-canonical encoding
+// reaching this far usually means a caller mis-encoded a value, not that it
+// intentionally wrapped around the field.
+func DeserializeFelt(encodedFelt string, curve string) (any, error) {
 	decodedFelt, err := hex.DecodeString(encodedFelt)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("structs: decoding felt hex: %w", err)
 	}
 
-	// Deserialize the decoded felt.
-	var deserializedFelt fr_bn254.Element
-	deserializedFelt.SetBytes(decodedFelt)
+	curveID, err := CurveID(curve)
+	if err != nil {
+		return nil, err
+	}
 
-	return deserializedFelt
+	switch curveID {
+	case ecc.BN254:
+		var felt fr_bn254.Element
+		felt.SetBytes(decodedFelt)
+		if !isCanonicalFelt(felt.Marshal(), decodedFelt) {
+			return nil, fmt.Errorf("structs: felt is not a canonical field element encoding")
+		}
+		return felt, nil
+	case ecc.BLS12_381:
+		var felt fr_bls12381.Element
+		felt.SetBytes(decodedFelt)
+		if !isCanonicalFelt(felt.Marshal(), decodedFelt) {
+			return nil, fmt.Errorf("structs: felt is not a canonical field element encoding")
+		}
+		return felt, nil
+	case ecc.BLS12_377:
+		var felt fr_bls12377.Element
+		felt.SetBytes(decodedFelt)
+		if !isCanonicalFelt(felt.Marshal(), decodedFelt) {
+			return nil, fmt.Errorf("structs: felt is not a canonical field element encoding")
+		}
+		return felt, nil
+	case ecc.BW6_761:
+		var felt fr_bw6761.Element
+		felt.SetBytes(decodedFelt)
+		if !isCanonicalFelt(felt.Marshal(), decodedFelt) {
+			return nil, fmt.Errorf("structs: felt is not a canonical field element encoding")
+		}
+		return felt, nil
+	case ecc.BLS24_315:
+		var felt fr_bls24315.Element
+		felt.SetBytes(decodedFelt)
+		if !isCanonicalFelt(felt.Marshal(), decodedFelt) {
+			return nil, fmt.Errorf("structs: felt is not a canonical field element encoding")
+		}
+		return felt, nil
+	default:
+		return nil, &UnsupportedCurveError{Curve: curve}
+	}
 }
 
-func DeserializeFelts(encodedFelts string) fr_bn254.Vector {
-	// Decode the received felts.
+// DeserializeFelts decodes a hex-encoded, binary-marshaled vector of field
+// elements into the fr.Vector of the given curve, boxed as any (see
+// DeserializeFelt). Like DeserializeFelt, it rejects a payload that doesn't
+// round-trip back through MarshalBinary unchanged, which is how a
+// non-canonical (or truncated/corrupt) per-element encoding surfaces: the
+// vector's UnmarshalBinary silently reduces each element modulo the field
+// rather than erroring on one that's out of range.
+func DeserializeFelts(encodedFelts string, curve string) (any, error) {
 	decodedFelts, err := hex.DecodeString(encodedFelts)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("structs: decoding felts hex: %w", err)
 	}
 
-	// Unpack and deserialize the decoded felts.
-	var deserializedFelts fr_bn254.Vector
-	deserializedFelts.UnmarshalBinary(decodedFelts)
+	curveID, err := CurveID(curve)
+	if err != nil {
+		return nil, err
+	}
 
-	return deserializedFelts
+	switch curveID {
+	case ecc.BN254:
+		felts, err := DecodeFeltsFrom(bytes.NewReader(decodedFelts))
+		if err != nil {
+			return nil, err
+		}
+		if want := 4 + feltSize*len(felts); want != len(decodedFelts) {
+			return nil, fmt.Errorf("structs: felts payload has %d trailing bytes", len(decodedFelts)-want)
+		}
+		return felts, nil
+	case ecc.BLS12_381:
+		var felts fr_bls12381.Vector
+		if err := felts.UnmarshalBinary(decodedFelts); err != nil {
+			return nil, fmt.Errorf("structs: decoding felts: %w", err)
+		}
+		if roundTrip, err := felts.MarshalBinary(); err != nil || !bytes.Equal(roundTrip, decodedFelts) {
+			return nil, fmt.Errorf("structs: felts are not a canonical field element vector encoding")
+		}
+		return felts, nil
+	case ecc.BLS12_377:
+		var felts fr_bls12377.Vector
+		if err := felts.UnmarshalBinary(decodedFelts); err != nil {
+			return nil, fmt.Errorf("structs: decoding felts: %w", err)
+		}
+		if roundTrip, err := felts.MarshalBinary(); err != nil || !bytes.Equal(roundTrip, decodedFelts) {
+			return nil, fmt.Errorf("structs: felts are not a canonical field element vector encoding")
+		}
+		return felts, nil
+	case ecc.BW6_761:
+		var felts fr_bw6761.Vector
+		if err := felts.UnmarshalBinary(decodedFelts); err != nil {
+			return nil, fmt.Errorf("structs: decoding felts: %w", err)
+		}
+		if roundTrip, err := felts.MarshalBinary(); err != nil || !bytes.Equal(roundTrip, decodedFelts) {
+			return nil, fmt.Errorf("structs: felts are not a canonical field element vector encoding")
+		}
+		return felts, nil
+	case ecc.BLS24_315:
+		var felts fr_bls24315.Vector
+		if err := felts.UnmarshalBinary(decodedFelts); err != nil {
+			return nil, fmt.Errorf("structs: decoding felts: %w", err)
+		}
+		if roundTrip, err := felts.MarshalBinary(); err != nil || !bytes.Equal(roundTrip, decodedFelts) {
+			return nil, fmt.Errorf("structs: felts are not a canonical field element vector encoding")
+		}
+		return felts, nil
+	default:
+		return nil, &UnsupportedCurveError{Curve: curve}
+	}
 }
 
 // Samples a felt and returns the encoded felt and the non-encoded felt.
@@ -56,7 +178,8 @@ func SampleEncodedFelts() (string, fr_bn254.Vector) {
 
 	felts := fr_bn254.Vector{felt1, felt2}
 
-	binaryFelts, _ := felts.MarshalBinary()
+	var buf bytes.Buffer
+	_ = EncodeFeltsTo(&buf, felts)
 
-	return hex.EncodeToString(binaryFelts), felts
+	return hex.EncodeToString(buf.Bytes()), felts
 }
@@ -0,0 +1,213 @@
+package structs
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// PointEncoding selects how a G1/G2 point's hex wire payload is packed.
+// Uncompressed carries both coordinates (the Marshal/Unmarshal pair the
+// ceremony package already reads phase-1 transcripts with); Compressed
+// carries only X plus a sign bit for Y, for callers that would rather pay
+// a SetBytes/Bytes decompression than the extra wire bytes.
+type PointEncoding int
+
+const (
+	Uncompressed PointEncoding = iota
+	Compressed
+)
+
+// Fixed encoded sizes for each (point, encoding) pair, in bytes.
+const (
+	g1UncompressedSize = 64
+	g1CompressedSize   = 32
+	g2UncompressedSize = 128
+	g2CompressedSize   = 64
+)
+
+// DeserializeG1Affine decodes a hex-encoded BN254 G1 point in encoding, and
+// rejects any point outside the curve's prime-order subgroup. G1's cofactor
+// is 1, so subgroup membership here is just the on-curve check gnark-crypto
+// already does inside Unmarshal/SetBytes — it's still checked explicitly so
+// callers get the same guarantee DeserializeG2Affine gives for G2, where the
+// cofactor is not 1 and the check is load-bearing.
+func DeserializeG1Affine(encodedPoint string, encoding PointEncoding) (bn254.G1Affine, error) {
+	decoded, err := hex.DecodeString(encodedPoint)
+	if err != nil {
+		return bn254.G1Affine{}, fmt.Errorf("structs: decoding G1 point hex: %w", err)
+	}
+
+	var point bn254.G1Affine
+	switch encoding {
+	case Uncompressed:
+		if err := point.Unmarshal(decoded); err != nil {
+			return bn254.G1Affine{}, fmt.Errorf("structs: unmarshaling G1 point: %w", err)
+		}
+	case Compressed:
+		if _, err := point.SetBytes(decoded); err != nil {
+			return bn254.G1Affine{}, fmt.Errorf("structs: decompressing G1 point: %w", err)
+		}
+	default:
+		return bn254.G1Affine{}, fmt.Errorf("structs: unknown point encoding %d", encoding)
+	}
+
+	if !point.IsInSubGroup() {
+		return bn254.G1Affine{}, fmt.Errorf("structs: G1 point is not in the prime-order subgroup")
+	}
+	return point, nil
+}
+
+// DeserializeG2Affine decodes a hex-encoded BN254 G2 point in encoding, and
+// rejects any point outside the prime-order subgroup (G2's cofactor is not
+// 1, so an on-curve point need not be a valid subgroup element).
+func DeserializeG2Affine(encodedPoint string, encoding PointEncoding) (bn254.G2Affine, error) {
+	decoded, err := hex.DecodeString(encodedPoint)
+	if err != nil {
+		return bn254.G2Affine{}, fmt.Errorf("structs: decoding G2 point hex: %w", err)
+	}
+
+	var point bn254.G2Affine
+	switch encoding {
+	case Uncompressed:
+		if err := point.Unmarshal(decoded); err != nil {
+			return bn254.G2Affine{}, fmt.Errorf("structs: unmarshaling G2 point: %w", err)
+		}
+	case Compressed:
+		if _, err := point.SetBytes(decoded); err != nil {
+			return bn254.G2Affine{}, fmt.Errorf("structs: decompressing G2 point: %w", err)
+		}
+	default:
+		return bn254.G2Affine{}, fmt.Errorf("structs: unknown point encoding %d", encoding)
+	}
+
+	if !point.IsInSubGroup() {
+		return bn254.G2Affine{}, fmt.Errorf("structs: G2 point is not in the prime-order subgroup")
+	}
+	return point, nil
+}
+
+// DeserializeG1Affines decodes a hex-encoded sequence of fixed-size G1
+// points (back-to-back, no length prefix — the caller knows how many
+// points it sent; unlike DeserializeFelts' vector, which starts with a
+// 4-byte element count).
+func DeserializeG1Affines(encodedPoints string, encoding PointEncoding) ([]bn254.G1Affine, error) {
+	decoded, err := hex.DecodeString(encodedPoints)
+	if err != nil {
+		return nil, fmt.Errorf("structs: decoding G1 vector hex: %w", err)
+	}
+
+	size := g1UncompressedSize
+	if encoding == Compressed {
+		size = g1CompressedSize
+	}
+	if len(decoded)%size != 0 {
+		return nil, fmt.Errorf("structs: G1 vector length %d is not a multiple of %d", len(decoded), size)
+	}
+
+	points := make([]bn254.G1Affine, len(decoded)/size)
+	for i := range points {
+		chunk := decoded[i*size : (i+1)*size]
+		var err error
+		if encoding == Compressed {
+			_, err = points[i].SetBytes(chunk)
+		} else {
+			err = points[i].Unmarshal(chunk)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("structs: point %d: %w", i, err)
+		}
+		if !points[i].IsInSubGroup() {
+			return nil, fmt.Errorf("structs: point %d is not in the prime-order subgroup", i)
+		}
+	}
+	return points, nil
+}
+
+// DeserializeG2Affines is DeserializeG1Affines for G2.
+func DeserializeG2Affines(encodedPoints string, encoding PointEncoding) ([]bn254.G2Affine, error) {
+	decoded, err := hex.DecodeString(encodedPoints)
+	if err != nil {
+		return nil, fmt.Errorf("structs: decoding G2 vector hex: %w", err)
+	}
+
+	size := g2UncompressedSize
+	if encoding == Compressed {
+		size = g2CompressedSize
+	}
+	if len(decoded)%size != 0 {
+		return nil, fmt.Errorf("structs: G2 vector length %d is not a multiple of %d", len(decoded), size)
+	}
+
+	points := make([]bn254.G2Affine, len(decoded)/size)
+	for i := range points {
+		chunk := decoded[i*size : (i+1)*size]
+		var err error
+		if encoding == Compressed {
+			_, err = points[i].SetBytes(chunk)
+		} else {
+			err = points[i].Unmarshal(chunk)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("structs: point %d: %w", i, err)
+		}
+		if !points[i].IsInSubGroup() {
+			return nil, fmt.Errorf("structs: point %d is not in the prime-order subgroup", i)
+		}
+	}
+	return points, nil
+}
+
+// DeserializeWitnessValue decodes a single hex-encoded raw witness value
+// according to kind: FeltKind dispatches to DeserializeFelt, PointKind to
+// DeserializeG1Affine (Uncompressed encoding). Both return their decoded
+// value boxed as any, the same convention DeserializeFelt already uses, so
+// a caller assigning a mixed felt/point witness can hold them in a single
+// slice without a type switch of its own. This is the function
+// WitnessKind's PointKind case exists to reach: without it, PointKind was
+// a constant nothing in this module ever read.
+func DeserializeWitnessValue(kind WitnessKind, encoded string, curve string) (any, error) {
+	switch kind {
+	case FeltKind:
+		return DeserializeFelt(encoded, curve)
+	case PointKind:
+		return DeserializeG1Affine(encoded, Uncompressed)
+	default:
+		return nil, fmt.Errorf("structs: unknown witness kind %d", kind)
+	}
+}
+
+// SampleEncodedG1 samples a random G1 point (a random scalar multiple of
+// the generator, so it's always in the subgroup) and returns it alongside
+// its hex-encoded Uncompressed form, mirroring SampleEncodedFelt.
+func SampleEncodedG1() (string, bn254.G1Affine) {
+	_, _, g1Gen, _ := bn254.Generators()
+
+	var scalar fr.Element
+	scalar.SetRandom()
+	var scalarBig big.Int
+	scalar.BigInt(&scalarBig)
+
+	var point bn254.G1Affine
+	point.ScalarMultiplication(&g1Gen, &scalarBig)
+
+	return hex.EncodeToString(point.Marshal()), point
+}
+
+// SampleEncodedG2 is SampleEncodedG1 for G2.
+func SampleEncodedG2() (string, bn254.G2Affine) {
+	_, _, _, g2Gen := bn254.Generators()
+
+	var scalar fr.Element
+	scalar.SetRandom()
+	var scalarBig big.Int
+	scalar.BigInt(&scalarBig)
+
+	var point bn254.G2Affine
+	point.ScalarMultiplication(&g2Gen, &scalarBig)
+
+	return hex.EncodeToString(point.Marshal()), point
+}
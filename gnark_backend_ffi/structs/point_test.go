@@ -0,0 +1,140 @@
+package structs
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// nonSubgroupG2Point returns a point that satisfies G2's curve equation but
+// is (overwhelmingly likely to be) outside the prime-order subgroup: G2's
+// cofactor on BN254 is large, so a uniformly random on-curve point lands in
+// the subgroup with negligible probability. B is recovered from the known
+// generator (Y² - X³) rather than the package's own unexported curve
+// coefficient, since that's all this test can reach from outside bn254.
+func nonSubgroupG2Point(t *testing.T) bn254.G2Affine {
+	t.Helper()
+
+	_, _, _, g2Gen := bn254.Generators()
+	var x3, y2, b bn254.G2Affine
+	x3.X.Square(&g2Gen.X).Mul(&x3.X, &g2Gen.X)
+	y2.X.Square(&g2Gen.Y)
+	b.X.Sub(&y2.X, &x3.X)
+
+	for i := 0; i < 1000; i++ {
+		var p bn254.G2Affine
+		p.X.SetRandom()
+
+		var x3, rhs bn254.G2Affine
+		x3.X.Square(&p.X).Mul(&x3.X, &p.X)
+		rhs.X.Add(&x3.X, &b.X)
+		if rhs.X.Legendre() != 1 {
+			continue
+		}
+		p.Y.Sqrt(&rhs.X)
+
+		if !p.IsOnCurve() {
+			continue
+		}
+		if p.IsInSubGroup() {
+			continue
+		}
+		return p
+	}
+
+	t.Fatal("nonSubgroupG2Point: failed to find an on-curve, off-subgroup point after 1000 tries")
+	return bn254.G2Affine{}
+}
+
+func TestDeserializeG1Affine_RoundTrip(t *testing.T) {
+	encoded, point := SampleEncodedG1()
+
+	decoded, err := DeserializeG1Affine(encoded, Uncompressed)
+	if err != nil {
+		t.Fatalf("DeserializeG1Affine: %v", err)
+	}
+	if !decoded.Equal(&point) {
+		t.Fatalf("DeserializeG1Affine: got %v, want %v", decoded, point)
+	}
+
+	compressedBytes := point.Bytes()
+	compressed := hex.EncodeToString(compressedBytes[:])
+	decodedCompressed, err := DeserializeG1Affine(compressed, Compressed)
+	if err != nil {
+		t.Fatalf("DeserializeG1Affine (compressed): %v", err)
+	}
+	if !decodedCompressed.Equal(&point) {
+		t.Fatalf("DeserializeG1Affine (compressed): got %v, want %v", decodedCompressed, point)
+	}
+}
+
+func TestDeserializeG2Affine_RoundTrip(t *testing.T) {
+	encoded, point := SampleEncodedG2()
+
+	decoded, err := DeserializeG2Affine(encoded, Uncompressed)
+	if err != nil {
+		t.Fatalf("DeserializeG2Affine: %v", err)
+	}
+	if !decoded.Equal(&point) {
+		t.Fatalf("DeserializeG2Affine: got %v, want %v", decoded, point)
+	}
+
+	compressedBytes := point.Bytes()
+	compressed := hex.EncodeToString(compressedBytes[:])
+	decodedCompressed, err := DeserializeG2Affine(compressed, Compressed)
+	if err != nil {
+		t.Fatalf("DeserializeG2Affine (compressed): %v", err)
+	}
+	if !decodedCompressed.Equal(&point) {
+		t.Fatalf("DeserializeG2Affine (compressed): got %v, want %v", decodedCompressed, point)
+	}
+}
+
+func TestDeserializeG2Affine_RejectsNonSubgroupPoint(t *testing.T) {
+	point := nonSubgroupG2Point(t)
+	encoded := hex.EncodeToString(point.Marshal())
+
+	if _, err := DeserializeG2Affine(encoded, Uncompressed); err == nil {
+		t.Fatal("DeserializeG2Affine: expected an error for a point outside the prime-order subgroup, got nil")
+	}
+}
+
+func TestDeserializeG1Affines_RoundTrip(t *testing.T) {
+	var encoded []byte
+	var points []bn254.G1Affine
+	for i := 0; i < 3; i++ {
+		e, p := SampleEncodedG1()
+		decoded, err := hex.DecodeString(e)
+		if err != nil {
+			t.Fatalf("decoding sample: %v", err)
+		}
+		encoded = append(encoded, decoded...)
+		points = append(points, p)
+	}
+
+	decoded, err := DeserializeG1Affines(hex.EncodeToString(encoded), Uncompressed)
+	if err != nil {
+		t.Fatalf("DeserializeG1Affines: %v", err)
+	}
+	if len(decoded) != len(points) {
+		t.Fatalf("DeserializeG1Affines: got %d points, want %d", len(decoded), len(points))
+	}
+	for i := range points {
+		if !decoded[i].Equal(&points[i]) {
+			t.Fatalf("DeserializeG1Affines: point %d: got %v, want %v", i, decoded[i], points[i])
+		}
+	}
+}
+
+func TestDeserializeG2Affines_RejectsTruncatedInput(t *testing.T) {
+	encoded, _ := SampleEncodedG2()
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("decoding sample: %v", err)
+	}
+
+	if _, err := DeserializeG2Affines(hex.EncodeToString(decoded[:len(decoded)-1]), Uncompressed); err == nil {
+		t.Fatal("DeserializeG2Affines: expected an error for a length that isn't a multiple of the point size, got nil")
+	}
+}
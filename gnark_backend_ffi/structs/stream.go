@@ -0,0 +1,76 @@
+package structs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// feltSize is the width, in bytes, of a single fr_bn254.Element's canonical
+// Marshal encoding (big-endian, despite gnark-crypto's internal Montgomery
+// representation).
+const feltSize = 32
+
+// EncodeFeltsTo writes v to w as a 4-byte big-endian element count followed
+// by each element's canonical 32-byte Marshal encoding, back to back.
+// Unlike DeserializeFelts/SampleEncodedFelts' hex helpers (which now
+// delegate here for BN254), this never buffers the whole vector as one hex
+// string: a caller that only has an io.Writer — a socket, a file — can
+// stream a multi-million-element ACIR witness out without paying for the
+// intermediate hex-encoded copy.
+func EncodeFeltsTo(w io.Writer, v fr_bn254.Vector) error {
+	var countBuf [4]byte
+	binary.BigEndian.PutUint32(countBuf[:], uint32(len(v)))
+	if _, err := w.Write(countBuf[:]); err != nil {
+		return fmt.Errorf("structs: writing felt count: %w", err)
+	}
+
+	for i, felt := range v {
+		if _, err := w.Write(felt.Marshal()); err != nil {
+			return fmt.Errorf("structs: writing felt %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// maxFeltCount caps the element count DecodeFeltsFrom will trust enough to
+// preallocate for. Without a cap, a crafted 4-byte header of 0xFFFFFFFF
+// would make DecodeFeltsFrom attempt a ~137GB allocation before io.ReadFull
+// ever gets a chance to fail on the short read that follows -- exactly the
+// untrusted-input scenario (a proving service decoding witness blobs off
+// the wire) this streaming codec exists to handle safely. 64Mi elements
+// (2GB of felts) is far beyond any witness this module's circuits build in
+// practice, while still leaving room for legitimate large vectors.
+const maxFeltCount = 1 << 26
+
+// DecodeFeltsFrom is EncodeFeltsTo's inverse. It reads incrementally with
+// io.ReadFull rather than assuming a single Read returns the whole count or
+// element — the idiomatic Go pattern for input of unknown backing, since a
+// buffered socket may hand back far fewer bytes than requested per Read.
+// Each element is rejected if it isn't a canonical field encoding, same as
+// DeserializeFelt/DeserializeFelts.
+func DecodeFeltsFrom(r io.Reader) (fr_bn254.Vector, error) {
+	var countBuf [4]byte
+	if _, err := io.ReadFull(r, countBuf[:]); err != nil {
+		return nil, fmt.Errorf("structs: reading felt count: %w", err)
+	}
+	count := binary.BigEndian.Uint32(countBuf[:])
+	if count > maxFeltCount {
+		return nil, fmt.Errorf("structs: felt count %d exceeds the maximum of %d", count, maxFeltCount)
+	}
+
+	felts := make(fr_bn254.Vector, count)
+	buf := make([]byte, feltSize)
+	for i := range felts {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, fmt.Errorf("structs: reading felt %d: %w", i, err)
+		}
+		felts[i].SetBytes(buf)
+		if !isCanonicalFelt(felts[i].Marshal(), buf) {
+			return nil, fmt.Errorf("structs: felt %d is not a canonical field element encoding", i)
+		}
+	}
+	return felts, nil
+}
@@ -0,0 +1,66 @@
+package structs
+
+// MulTerm is a Noir quadratic term: Coefficient * Values[Multiplicand] * Values[Multiplier].
+// Coefficient is carried as a hex-encoded field element and only decoded into a
+// curve-specific fr.Element once the target curve is known (see DeserializeFelt).
+type MulTerm struct {
+	Coefficient  string `json:"coefficient"`
+	Multiplicand uint32 `json:"multiplicand"`
+	Multiplier   uint32 `json:"multiplier"`
+}
+
+// AddTerm is a Noir linear term: Coefficient * Values[Sum].
+type AddTerm struct {
+	Coefficient string `json:"coefficient"`
+	Sum         uint32 `json:"sum"`
+}
+
+// RawGate is a single Noir arithmetic gate, read off the wire as:
+//
+//	sum(MulTerms) + sum(AddTerms) + ConstantTerm = 0
+type RawGate struct {
+	MulTerms     []MulTerm `json:"mul_terms"`
+	AddTerms     []AddTerm `json:"add_terms"`
+	ConstantTerm string    `json:"constant_term"`
+}
+
+// RawR1CS is the JSON representation of a Noir ACIR circuit handed across the FFI
+// boundary. Curve selects which gnark curve (and therefore which cs_*/fr_* package)
+// Values, the gate coefficients, and the constraint system built from Gates are
+// interpreted over. It is a string so new curves can be added without touching the
+// wire format, and defaults to "BN254" when absent so existing callers keep working.
+type RawR1CS struct {
+	Curve          string    `json:"curve"`
+	Gates          []RawGate `json:"gates"`
+	PublicInputs   []uint32  `json:"public_inputs"`
+	Values         string    `json:"values"`
+	NumVariables   uint32    `json:"num_variables"`
+	NumConstraints uint32    `json:"num_constraints"`
+}
+
+// WitnessKind distinguishes how a raw witness value should be decoded once
+// its curve is known: FeltKind values bind directly to a circuit's scalar
+// variables via DeserializeFelt, while PointKind values are BN254 G1 group
+// elements decoded via DeserializeG1Affine for precompile-style circuit
+// inputs (signature verification, pairing checks). DeserializeWitnessValue
+// is the dispatch point that reads this. No code in this module calls it:
+// circuit.Build decodes Noir's felts-only ACIR witness vector with
+// DeserializeFelts directly, and nothing here assigns a PointKind witness.
+type WitnessKind int
+
+const (
+	FeltKind WitnessKind = iota
+	PointKind
+)
+
+// DefaultCurve is the curve assumed for RawR1CS payloads that omit the "curve" field,
+// preserving the behavior of the original BN254-only FFI.
+const DefaultCurve = "BN254"
+
+// CurveOrDefault returns r.Curve, defaulting to DefaultCurve when unset.
+func (r RawR1CS) CurveOrDefault() string {
+	if r.Curve == "" {
+		return DefaultCurve
+	}
+	return r.Curve
+}